@@ -0,0 +1,177 @@
+package varint
+
+// GetAny sets the provided bits of any bit len to the integer inside VarInt at the provided index.
+// In case the provided bits are wider than the integer, the integer is zero extended to fit the bits.
+// It never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits are narrower than the integer value, the value is truncated
+// to fit the bits and extra ErrorBitLengthIsTruncated warning is returned.
+func (vint VarInt) GetAny(i int, bits Bits) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	blen, blenx := BitLen(vint), bits.BitLen()
+	words := (blen + wsize - 1) / wsize
+	// Iterate over all bits words and copy the integer words,
+	// zero extending or truncating them to the bits bit len.
+	var truncated bool
+	for k := 0; k+1 < len(bits); k++ {
+		var w uint
+		if k < words {
+			w = wget(vint, i, k)
+		}
+		if n := blenx - k*wsize; n < wsize {
+			truncated = truncated || w>>n != 0
+			w &= ^uint(0) >> (wsize - n)
+		}
+		bits[k+1] = w
+	}
+	// Check the integer words that don't fit into the bits at all.
+	from := len(bits) - 1
+	if from < 0 {
+		from = 0
+	}
+	for k := from; k < words && !truncated; k++ {
+		truncated = wget(vint, i, k) != 0
+	}
+	if truncated {
+		return ErrorBitLengthIsTruncated
+	}
+	return nil
+}
+
+// SetAny sets the provided bits of any bit len into the integer inside VarInt at the provided index.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the value is truncated
+// and extra ErrorBitLengthIsTruncated warning is returned.
+func (vint VarInt) SetAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.setv)
+}
+
+// AddAny adds the provided bits of any bit len to the integer inside VarInt at the provided index.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned, unless the operation returns any other warning.
+// In case the addition result overflows the bit len, the regular unsigned semantic applies and
+// extra ErrorAdditionOverflow warning is returned.
+func (vint VarInt) AddAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.addv)
+}
+
+// SubAny subtracts the provided bits of any bit len from the integer inside VarInt at the provided index.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned, unless the operation returns any other warning.
+// In case the subtraction result underflows the integer, the regular unsigned semantic applies and
+// extra ErrorSubtractionUnderflow warning is returned.
+func (vint VarInt) SubAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.subv)
+}
+
+// MulAny multiplies the provided bits of any bit len with the integer inside VarInt at the provided index.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned, unless the operation returns any other warning.
+// In case the multiplication result overflows the bit len, the integer is truncated and
+// extra ErrorMultiplicationOverflow warning is returned.
+func (vint VarInt) MulAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.mulv)
+}
+
+// DivAny divides the integer inside VarInt at the provided index by the provided bits of any bit len.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+func (vint VarInt) DivAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.divv)
+}
+
+// ModAny applies modulo operation to the integer inside VarInt at the provided index and the provided bits of any bit len.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+func (vint VarInt) ModAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.modv)
+}
+
+// AndAny applies bitwise and & operation to the integer inside VarInt at the provided index and the provided bits of any bit len.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned.
+func (vint VarInt) AndAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.andv)
+}
+
+// OrAny applies bitwise or | operation to the integer inside VarInt at the provided index and the provided bits of any bit len.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned.
+func (vint VarInt) OrAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.orv)
+}
+
+// XorAny applies bitwise xor ^ operation to the integer inside VarInt at the provided index and the provided bits of any bit len.
+// In case the provided bits are narrower than the integer, the bits are zero extended on the fly.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits value doesn't fit into the bit len, the truncated value is used
+// and extra ErrorBitLengthIsTruncated warning is returned.
+func (vint VarInt) XorAny(i int, bits Bits) error {
+	return vint.anyop(i, bits, VarInt.xorv)
+}
+
+// anyop internal helper that validates the provided index and applies the provided
+// element-wise core in place with the provided bits of any bit len used as the operand.
+// The core warnings and errors always take precedence, so the truncation
+// warning is returned only when the core operation succeeds.
+func (vint VarInt) anyop(i int, bits Bits, op func(VarInt, int, operand, VarInt) error) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	x := operand{bits: bits, blen: BitLen(vint)}
+	if err := op(vint, i, x, vint); err != nil {
+		return err
+	}
+	if x.truncated() {
+		return ErrorBitLengthIsTruncated
+	}
+	return nil
+}
+
+// setv internal element-wise core of SetAny operation.
+func (vint VarInt) setv(i int, x operand, dst VarInt) error {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, x.word(k))
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntAnyOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i    int
+			bits Bits
+			err  error
+		}{
+			"any operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"any operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    -1,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorIndexIsNegative,
+			},
+			"any operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"any operations should return division by zero error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				bits: NewBits(3*wsize, []uint{0, 0, 1 << len}),
+				err:  ErrorDivisionByZero,
+			},
+			"any operations should return bit length is truncated warning": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				bits: NewBits(3*wsize, []uint{1, 0, 1 << len}),
+				err:  ErrorBitLengthIsTruncated,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				if tcase.err == ErrorDivisionByZero {
+					h.Equal(tcase.vint.DivAny(tcase.i, tcase.bits), tcase.err)
+					h.Equal(tcase.vint.ModAny(tcase.i, tcase.bits), tcase.err)
+					return
+				}
+				h.Equal(tcase.vint.SetAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.AddAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.SubAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.MulAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.DivAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.ModAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.AndAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.OrAny(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.XorAny(tcase.i, tcase.bits), tcase.err)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply random operation with random bit len operand
+		// and compare the result and the warning with the same
+		// big.Int operation applied to the truncated operand.
+		for k := 0; k < 500; k++ {
+			blens := []int{1, 7, 63, 64, 65, 100, 128, 129, 200}
+			blen, blenx, l := blens[rnd.Int()%9], blens[rnd.Int()%9], rnd.Int()%10+1
+			i := rnd.Int() % l
+			vint := h.NewVarInt(blen, l)
+			for x := 0; x < l; x++ {
+				_ = vint.Set(x, NewBitsRand(blen, rnd))
+			}
+			bits := NewBitsRand(blenx, rnd)
+			// Make sure small values and zeros are
+			// used as operands reasonably often.
+			switch rnd.Int() % 4 {
+			case 0:
+				bits = NewBits(blenx, nil)
+			case 1:
+				bits = NewBits(blenx, []uint{uint(rnd.Int() % (blen + 2))})
+			}
+			vintc := append(VarInt(nil), vint...)
+			ai := h.VarIntGet(i)
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			mask := new(big.Int).Sub(lim, big.NewInt(1))
+			a, b, r := ai.BigInt(), bits.BigInt(), new(big.Int)
+			var truncated error
+			if b.Cmp(lim) >= 0 {
+				truncated = ErrorBitLengthIsTruncated
+				b.And(b, mask)
+			}
+			var err, expected error
+			switch op := rnd.Int() % 10; op {
+			case 0:
+				if r.Add(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorAdditionOverflow
+				}
+				err = vint.AddAny(i, bits)
+			case 1:
+				if r.Sub(a, b); r.Sign() < 0 {
+					expected = ErrorSubtractionUnderflow
+				}
+				err = vint.SubAny(i, bits)
+			case 2:
+				if r.Mul(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorMultiplicationOverflow
+				}
+				err = vint.MulAny(i, bits)
+			case 3, 4:
+				switch {
+				case b.Sign() == 0:
+					expected = ErrorDivisionByZero
+					r.Set(a)
+				case op == 3:
+					r.Quo(a, b)
+				default:
+					r.Rem(a, b)
+				}
+				if op == 3 {
+					err = vint.DivAny(i, bits)
+				} else {
+					err = vint.ModAny(i, bits)
+				}
+			case 5:
+				r.And(a, b)
+				err = vint.AndAny(i, bits)
+			case 6:
+				r.Or(a, b)
+				err = vint.OrAny(i, bits)
+			case 7:
+				r.Xor(a, b)
+				err = vint.XorAny(i, bits)
+			case 8:
+				r.Set(b)
+				err = vint.SetAny(i, bits)
+			default:
+				// Get is checked separately against
+				// the bits bit len truncation instead.
+				bits = NewBits(blenx, nil)
+				limx := new(big.Int).Lsh(big.NewInt(1), uint(blenx))
+				if a.Cmp(limx) >= 0 {
+					expected = ErrorBitLengthIsTruncated
+				}
+				h.Equal(vint.GetAny(i, bits), expected)
+				h.Equal(bits, NewBitsBits(blenx, NewBitsBigInt(r.And(a, limx.Sub(limx, big.NewInt(1))))))
+				h.Equal(vint, vintc)
+				continue
+			}
+			if expected == nil {
+				expected = truncated
+			}
+			h.Equal(err, expected)
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.And(r, mask))))
+			// Restore the changed integer and check that
+			// all other integers are left unchanged.
+			h.VarIntSet(i, ai)
+			_, _ = bvar(vint, true), bvar(vintc, true)
+			h.Equal(vint, vintc)
+		}
+	})
+	test("Overflow", t, func(h h) {
+		// Rejected or saturated operation with truncated operand has
+		// to return the operation error instead of the truncation warning,
+		// while succeeded operation still returns the truncation warning.
+		for _, overflow := range []Overflow{OverflowWrap, OverflowSaturate, OverflowReject} {
+			vint, _ := NewVarIntOverflow(8, len, overflow)
+			h.VarInt = vint
+			h.VarIntSet(0, NewBits(8, []uint{200}))
+			h.Equal(vint.AddAny(0, NewBits(16, []uint{1000})), ErrorAdditionOverflow)
+			switch overflow {
+			case OverflowWrap:
+				h.VarIntEqual(0, NewBits(8, []uint{176}))
+			case OverflowSaturate:
+				h.VarIntEqual(0, NewBits(8, []uint{255}))
+			default:
+				h.VarIntEqual(0, NewBits(8, []uint{200}))
+			}
+			h.VarIntSet(0, NewBits(8, []uint{200}))
+			h.Equal(vint.AddAny(0, NewBits(16, []uint{266})), ErrorBitLengthIsTruncated)
+			h.VarIntEqual(0, NewBits(8, []uint{210}))
+		}
+	})
+}
@@ -0,0 +1,225 @@
+package varint
+
+// AddAt adds the integer inside VarInt at the provided operand index j to the integer at the provided index i.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the addition result overflows the bit len, the regular unsigned semantic applies and
+// extra ErrorAdditionOverflow warning is returned.
+func (vint VarInt) AddAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.addv(i, operand{vint: vint, j: j}, vint)
+}
+
+// SubAt subtracts the integer inside VarInt at the provided operand index j from the integer at the provided index i.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the subtraction result underflows the integer, the regular unsigned semantic applies and
+// extra ErrorSubtractionUnderflow warning is returned.
+func (vint VarInt) SubAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.subv(i, operand{vint: vint, j: j}, vint)
+}
+
+// MulAt multiplies the integer inside VarInt at the provided operand index j with the integer at the provided index i.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the multiplication result overflows the bit len, the integer is truncated and
+// extra ErrorMultiplicationOverflow warning is returned.
+func (vint VarInt) MulAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.mulv(i, operand{vint: vint, j: j}, vint)
+}
+
+// DivAt divides the integer inside VarInt at the provided index i by the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+func (vint VarInt) DivAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.divv(i, operand{vint: vint, j: j}, vint)
+}
+
+// ModAt applies modulo operation to the integer inside VarInt at the provided index i
+// and the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+func (vint VarInt) ModAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.modv(i, operand{vint: vint, j: j}, vint)
+}
+
+// AndAt applies bitwise and & operation to the integer inside VarInt at the provided index i
+// and the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) AndAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.andv(i, operand{vint: vint, j: j}, vint)
+}
+
+// OrAt applies bitwise or | operation to the integer inside VarInt at the provided index i
+// and the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) OrAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.orv(i, operand{vint: vint, j: j}, vint)
+}
+
+// XorAt applies bitwise xor ^ operation to the integer inside VarInt at the provided index i
+// and the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) XorAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.xorv(i, operand{vint: vint, j: j}, vint)
+}
+
+// RshAt applies right shift >> operation to the integer inside VarInt at the provided index i
+// by the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) RshAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.rshv(i, operand{vint: vint, j: j}, vint)
+}
+
+// LshAt applies left shift << operation to the integer inside VarInt at the provided index i
+// by the integer at the provided operand index j.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) LshAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	return vint.lshv(i, operand{vint: vint, j: j}, vint)
+}
+
+// CmpAt returns an integer comparing of the integers inside VarInt at the provided indexes i and j.
+// The result is 0 if vint[i] == vint[j], -1 if vint[i] < vint[j], and +1 if vint[i] > vint[j].
+// It never mutates any integer, doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) CmpAt(i, j int) (int, error) {
+	if err := vint.cat(i, j); err != nil {
+		return 0, err
+	}
+	return vint.cmpv(i, operand{vint: vint, j: j}), nil
+}
+
+// Cmp is an alias of CmpAt that compares the integers
+// inside VarInt at the provided indexes i and j.
+// See CmpAt for more details.
+func (vint VarInt) Cmp(i, j int) (int, error) {
+	return vint.CmpAt(i, j)
+}
+
+// CmpBits returns an integer comparing of the integer inside VarInt at the provided index and the provided bits.
+// The result is 0 if vint[i] == bits, -1 if vint[i] < bits, and +1 if vint[i] > bits.
+// It reads the packed words directly, so it never mutates any integer and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) CmpBits(i int, bits Bits) (int, error) {
+	if err := vint.cbits(i, bits); err != nil {
+		return 0, err
+	}
+	return vint.cmpv(i, operand{bits: bits, blen: BitLen(vint)}), nil
+}
+
+// SwapAt swaps the integers inside VarInt at the provided indexes i and j.
+// It doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) SwapAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	vint.swapv(i, vint, j)
+	return nil
+}
+
+// cat internal helper that validates
+// the provided indexes against VarInt.
+func (vint VarInt) cat(i, j int) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	return vint.cany(j)
+}
+
+// cmpv internal helper that compares the integer inside VarInt
+// at the provided index i with the provided operand word by word.
+func (vint VarInt) cmpv(i int, x operand) int {
+	// Iterate from high to low word
+	// and compare the integers words.
+	for k := (BitLen(vint)+wsize-1)/wsize - 1; k >= 0; k-- {
+		switch wi, wj := wget(vint, i, k), x.word(k); {
+		case wi < wj:
+			return -1
+		case wi > wj:
+			return 1
+		}
+	}
+	return 0
+}
+
+// swapv internal helper that swaps the integer inside VarInt at the provided index i
+// with the integer inside the provided VarInt at the provided index j word by word.
+func (vint VarInt) swapv(i int, vintx VarInt, j int) {
+	for k, words := 0, (BitLen(vint)+wsize-1)/wsize; k < words; k++ {
+		wi, wj := wget(vint, i, k), wget(vintx, j, k)
+		wset(vint, i, k, wj)
+		wset(vintx, j, k, wi)
+	}
+}
+
+// movev internal helper that sets the integer inside VarInt at the provided index i
+// to the integer inside the provided VarInt at the provided index j word by word.
+func (vint VarInt) movev(i int, vintx VarInt, j int) {
+	for k, words := 0, (BitLen(vint)+wsize-1)/wsize; k < words; k++ {
+		wset(vint, i, k, wget(vintx, j, k))
+	}
+}
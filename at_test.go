@@ -0,0 +1,173 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntAtOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i, j int
+			err  error
+		}{
+			"at operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				j:    2,
+				err:  ErrorVarIntIsInvalid,
+			},
+			"at operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				j:    -1,
+				err:  ErrorIndexIsNegative,
+			},
+			"at operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				j:    1,
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"at operations should return division by zero error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				j:    2,
+				err:  ErrorDivisionByZero,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(tcase.vint.DivAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.ModAt(tcase.i, tcase.j), tcase.err)
+				if tcase.err == ErrorDivisionByZero {
+					return
+				}
+				h.Equal(tcase.vint.AddAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.SubAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.MulAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.AndAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.OrAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.XorAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.RshAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.LshAt(tcase.i, tcase.j), tcase.err)
+				h.Equal(tcase.vint.SwapAt(tcase.i, tcase.j), tcase.err)
+				_, err := tcase.vint.CmpAt(tcase.i, tcase.j)
+				h.Equal(err, tcase.err)
+				_, err = tcase.vint.Cmp(tcase.i, tcase.j)
+				h.Equal(err, tcase.err)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply random index to index operation and compare the
+		// result and the warning with the same big.Int operation,
+		// then check that all other integers are left unchanged.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 63, 64, 65, 100, 128, 129, 200}[rnd.Int()%9], rnd.Int()%10+1
+			i, j := rnd.Int()%l, rnd.Int()%l
+			vint := h.NewVarInt(blen, l)
+			for x := 0; x < l; x++ {
+				b := NewBitsRand(blen, rnd)
+				// Make sure small values and zeros are
+				// used as operands reasonably often.
+				switch rnd.Int() % 4 {
+				case 0:
+					b = NewBits(blen, nil)
+				case 1:
+					b = NewBits(blen, []uint{uint(rnd.Int() % (blen + 2))})
+				}
+				_ = vint.Set(x, b)
+			}
+			vintc := append(VarInt(nil), vint...)
+			ai, aj := h.VarIntGet(i), h.VarIntGet(j)
+			a, b, r := ai.BigInt(), aj.BigInt(), new(big.Int)
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			var err, expected error
+			switch op := rnd.Int() % 12; op {
+			case 0:
+				if r.Add(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorAdditionOverflow
+				}
+				err = vint.AddAt(i, j)
+			case 1:
+				if r.Sub(a, b); r.Sign() < 0 {
+					expected = ErrorSubtractionUnderflow
+				}
+				err = vint.SubAt(i, j)
+			case 2:
+				if r.Mul(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorMultiplicationOverflow
+				}
+				err = vint.MulAt(i, j)
+			case 3, 4:
+				switch {
+				case b.Sign() == 0:
+					expected = ErrorDivisionByZero
+					r.Set(a)
+				case op == 3:
+					r.Quo(a, b)
+				default:
+					r.Rem(a, b)
+				}
+				if op == 3 {
+					err = vint.DivAt(i, j)
+				} else {
+					err = vint.ModAt(i, j)
+				}
+			case 5:
+				r.And(a, b)
+				err = vint.AndAt(i, j)
+			case 6:
+				r.Or(a, b)
+				err = vint.OrAt(i, j)
+			case 7:
+				r.Xor(a, b)
+				err = vint.XorAt(i, j)
+			case 8:
+				if b.IsUint64() && b.Uint64() < uint64(blen) {
+					r.Rsh(a, uint(b.Uint64()))
+				}
+				err = vint.RshAt(i, j)
+			case 9:
+				if b.IsUint64() && b.Uint64() < uint64(blen) {
+					r.Lsh(a, uint(b.Uint64()))
+				}
+				err = vint.LshAt(i, j)
+			case 10:
+				// Swap is checked separately for both
+				// integers as the only two changed.
+				h.NoError(vint.SwapAt(i, j))
+				h.VarIntEqual(i, aj)
+				h.VarIntEqual(j, ai)
+				h.NoError(vint.SwapAt(i, j))
+				_, _ = bvar(vint, true), bvar(vintc, true)
+				h.Equal(vint, vintc)
+				continue
+			default:
+				// Compare is checked to return the same
+				// result and to never mutate the integers.
+				cmp, err := vint.CmpAt(i, j)
+				h.NoError(err)
+				h.Equal(cmp, a.Cmp(b))
+				cmp, err = vint.Cmp(i, j)
+				h.NoError(err)
+				h.Equal(cmp, a.Cmp(b))
+				cmp, err = vint.CmpBits(i, aj)
+				h.NoError(err)
+				h.Equal(cmp, a.Cmp(b))
+				h.Equal(vint, vintc)
+				continue
+			}
+			h.Equal(err, expected)
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.And(r, new(big.Int).Sub(lim, big.NewInt(1))))))
+			// Restore the changed integer and check that
+			// all other integers are left unchanged.
+			h.VarIntSet(i, ai)
+			_, _ = bvar(vint, true), bvar(vintc, true)
+			h.Equal(vint, vintc)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package varint
+
+import math_bits "math/bits"
+
+// OnesCount returns the number of one bits, population count, of the integer inside VarInt at the provided index.
+// It reads the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) OnesCount(i int) (int, error) {
+	if err := vint.cany(i); err != nil {
+		return 0, err
+	}
+	var n int
+	for k, words := 0, (BitLen(vint)+wsize-1)/wsize; k < words; k++ {
+		n += math_bits.OnesCount(wget(vint, i, k))
+	}
+	return n, nil
+}
+
+// LeadingZeros returns the number of leading zero bits of the integer inside VarInt at the provided index
+// relative to the bit len of VarInt, the result is the bit len for 0 integer.
+// It reads the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) LeadingZeros(i int) (int, error) {
+	if err := vint.cany(i); err != nil {
+		return 0, err
+	}
+	return BitLen(vint) - vint.nbits(i), nil
+}
+
+// TrailingZeros returns the number of trailing zero bits of the integer inside VarInt at the provided index,
+// the result is the bit len for 0 integer.
+// It reads the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) TrailingZeros(i int) (int, error) {
+	if err := vint.cany(i); err != nil {
+		return 0, err
+	}
+	if n := vint.tz(i); n >= 0 {
+		return n, nil
+	}
+	return BitLen(vint), nil
+}
+
+// Len returns the minimum number of bits required to represent the integer inside VarInt at the provided index,
+// the result is 0 for 0 integer. Note that unlike package level Len, it's applied to a single integer.
+// It reads the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) Len(i int) (int, error) {
+	if err := vint.cany(i); err != nil {
+		return 0, err
+	}
+	return vint.nbits(i), nil
+}
+
+// Bit returns the value of the k-th bit, either 0 or 1, of the integer inside VarInt at the provided index,
+// bits are counted from the least significant one.
+// It reads the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bit index is negative or not lower than bit len, ErrorBitIndexIsOutOfRange is returned.
+func (vint VarInt) Bit(i, k int) (uint, error) {
+	if err := vint.cbit(i, k); err != nil {
+		return 0, err
+	}
+	return wget(vint, i, k/wsize) >> (k % wsize) & 1, nil
+}
+
+// SetBit sets the k-th bit of the integer inside VarInt at the provided index to the provided value,
+// bits are counted from the least significant one, any non zero value sets the bit to 1.
+// It writes the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bit index is negative or not lower than bit len, ErrorBitIndexIsOutOfRange is returned.
+func (vint VarInt) SetBit(i, k int, v uint) error {
+	if err := vint.cbit(i, k); err != nil {
+		return err
+	}
+	w, m := wget(vint, i, k/wsize), uint(1)<<(k%wsize)
+	if v != 0 {
+		w |= m
+	} else {
+		w &^= m
+	}
+	wset(vint, i, k/wsize, w)
+	return nil
+}
+
+// FlipBit inverts the k-th bit of the integer inside VarInt at the provided index,
+// bits are counted from the least significant one.
+// It writes the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bit index is negative or not lower than bit len, ErrorBitIndexIsOutOfRange is returned.
+func (vint VarInt) FlipBit(i, k int) error {
+	if err := vint.cbit(i, k); err != nil {
+		return err
+	}
+	wset(vint, i, k/wsize, wget(vint, i, k/wsize)^uint(1)<<(k%wsize))
+	return nil
+}
+
+// cbit internal helper that validates
+// the provided index and bit index against VarInt.
+func (vint VarInt) cbit(i, k int) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	// Check that requested bit index is inside bit len range.
+	if k < 0 || k >= BitLen(vint) {
+		return ErrorBitIndexIsOutOfRange
+	}
+	return nil
+}
+
+// nbits internal helper that returns the number of significant
+// bits of the integer inside VarInt at the provided index.
+func (vint VarInt) nbits(i int) int {
+	for k := (BitLen(vint)+wsize-1)/wsize - 1; k >= 0; k-- {
+		if w := wget(vint, i, k); w != 0 {
+			return k*wsize + math_bits.Len(w)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,138 @@
+package varint
+
+import (
+	"math/big"
+	math_bits "math/bits"
+	"testing"
+)
+
+func TestVarIntBitOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i    int
+			k    int
+			err  error
+		}{
+			"bit operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				k:    1,
+				err:  ErrorVarIntIsInvalid,
+			},
+			"bit operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    -1,
+				k:    1,
+				err:  ErrorIndexIsNegative,
+			},
+			"bit operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				k:    1,
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"bit operations should return negative bit index error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				k:    -1,
+				err:  ErrorBitIndexIsOutOfRange,
+			},
+			"bit operations should return bit index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				k:    len,
+				err:  ErrorBitIndexIsOutOfRange,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				// Bit count operations don't have any bit index to validate.
+				if tcase.err != ErrorBitIndexIsOutOfRange {
+					_, err := tcase.vint.OnesCount(tcase.i)
+					h.Equal(err, tcase.err)
+					_, err = tcase.vint.LeadingZeros(tcase.i)
+					h.Equal(err, tcase.err)
+					_, err = tcase.vint.TrailingZeros(tcase.i)
+					h.Equal(err, tcase.err)
+					_, err = tcase.vint.Len(tcase.i)
+					h.Equal(err, tcase.err)
+				}
+				_, err := tcase.vint.Bit(tcase.i, tcase.k)
+				h.Equal(err, tcase.err)
+				h.Equal(tcase.vint.SetBit(tcase.i, tcase.k, 1), tcase.err)
+				h.Equal(tcase.vint.FlipBit(tcase.i, tcase.k), tcase.err)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply all bit operations to both VarInt and Bits
+		// and compare the result with the same big.Int operations.
+		for k := 0; k < 1000; k++ {
+			blen, l := []int{1, 7, 13, 61, 63, 64, 65, 128, 129, 200, 521}[rnd.Int()%11], rnd.Int()%4+1
+			i := rnd.Int() % l
+			vint := h.NewVarInt(blen, l)
+			for x := 0; x < l; x++ {
+				h.VarIntSet(x, NewBitsRand(blen, rnd))
+			}
+			// Make sure zero and sparse integers are checked as well.
+			switch rnd.Int() % 4 {
+			case 0:
+				h.VarIntSet(i, NewBits(blen, nil))
+			case 1:
+				h.VarIntSet(i, NewBits(blen, nil).SetBit(rnd.Int()%blen, 1))
+			}
+			bits := h.VarIntGet(i)
+			a := bits.BigInt()
+			var ones int
+			for _, w := range a.Bits() {
+				ones += math_bits.OnesCount(uint(w))
+			}
+			tz := blen
+			if a.Sign() != 0 {
+				tz = int(a.TrailingZeroBits())
+			}
+			n, err := vint.OnesCount(i)
+			h.NoError(err)
+			h.Equal(n, ones)
+			h.Equal(bits.OnesCount(), ones)
+			n, err = vint.LeadingZeros(i)
+			h.NoError(err)
+			h.Equal(n, blen-a.BitLen())
+			h.Equal(bits.LeadingZeros(), blen-a.BitLen())
+			n, err = vint.TrailingZeros(i)
+			h.NoError(err)
+			h.Equal(n, tz)
+			h.Equal(bits.TrailingZeros(), tz)
+			n, err = vint.Len(i)
+			h.NoError(err)
+			h.Equal(n, a.BitLen())
+			h.Equal(bits.Len(), a.BitLen())
+			// Check single bit operations on random bit index.
+			b := rnd.Int() % blen
+			v, err := vint.Bit(i, b)
+			h.NoError(err)
+			h.Equal(v, a.Bit(b))
+			h.Equal(bits.Bit(b), a.Bit(b))
+			r := new(big.Int)
+			switch rnd.Int() % 3 {
+			case 0:
+				r.SetBit(a, b, 1)
+				h.NoError(vint.SetBit(i, b, 1))
+				h.Equal(bits.SetBit(b, 1), NewBitsBits(blen, NewBitsBigInt(r)))
+			case 1:
+				r.SetBit(a, b, 0)
+				h.NoError(vint.SetBit(i, b, 0))
+				h.Equal(bits.SetBit(b, 0), NewBitsBits(blen, NewBitsBigInt(r)))
+			case 2:
+				r.SetBit(a, b, a.Bit(b)^1)
+				h.NoError(vint.FlipBit(i, b))
+				h.Equal(bits.FlipBit(b), NewBitsBits(blen, NewBitsBigInt(r)))
+			}
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r)))
+			// Check that the provided Bits instance is never mutated.
+			h.Equal(bits.BigInt(), a)
+		}
+	})
+}
@@ -114,6 +114,32 @@ func NewBitsBigInt(i *big.Int) Bits {
 	return NewBits(i.BitLen(), bytes)
 }
 
+// NewBitsBigIntSigned allocates, copies and returns new Bits instance from the provided
+// big.Int as two's complement integer of the provided bit length. In case the provided big.Int
+// doesn't fit into the provided bit length, it is truncated to fit into the provided bit len.
+// In case the provided bit len is negative number, actual bit len is deduced to exactly fit
+// the provided number including its sign bit. In case nil is provided it's treated as 0.
+// See Bits type for more details.
+func NewBitsBigIntSigned(blen int, i *big.Int) Bits {
+	if i == nil {
+		i = big.NewInt(0)
+	}
+	// Special marker, deduce min bits size from the magnitude
+	// for non negative numbers and from the magnitude - 1
+	// for negative numbers, plus one extra sign bit.
+	if blen < 0 {
+		blen = i.BitLen() + 1
+		if i.Sign() < 0 {
+			blen = big.NewInt(0).Not(i).BitLen() + 1
+		}
+	}
+	// Truncate the number to the bit len, note that big.Int
+	// bitwise operations use two's complement representation.
+	mask := big.NewInt(0).Lsh(big.NewInt(1), uint(blen))
+	mask = mask.Sub(mask, big.NewInt(1))
+	return NewBitsBits(blen, NewBitsBigInt(big.NewInt(0).And(i, mask)))
+}
+
 // NewBitsString parses, allocates and returns new Bits instance
 // from the provided string and base, it deduces bit length to exactly fit
 // the provided number. Valid base values are inside [2, 62], base values below 2 are
@@ -231,6 +257,121 @@ func (bits Bits) Bytes() []uint {
 	return bits[1:]
 }
 
+// Rol returns new Bits instance with the bits rotated to the left within the bit length.
+// The provided shift is taken modulo the bit length, negative shift rotates to the right instead.
+// Note that unlike VarInt Rol, which returns ErrorShiftIsNegative, Bits Rol never fails on negative shift.
+// It's safe to use on nil Bits, the Bits instance is returned as is.
+func (bits Bits) Rol(n int) Bits {
+	blen := bits.BitLen()
+	if blen == 0 {
+		return bits
+	}
+	// Normalize the shift to the left
+	// rotation in range [0, BitLen).
+	if n %= blen; n < 0 {
+		n += blen
+	}
+	bytes := bits.Bytes()
+	words := blen/wsize + (blen%wsize+wsize-1)/wsize
+	rbytes := make([]uint, words)
+	for k := range rbytes {
+		rbytes[k] = wshift(bytes, -n, k) | wshift(bytes, blen-n, k)
+	}
+	return NewBits(blen, rbytes)
+}
+
+// Ror returns new Bits instance with the bits rotated to the right within the bit length.
+// The provided shift is taken modulo the bit length, negative shift rotates to the left instead.
+// Note that unlike VarInt Ror, which returns ErrorShiftIsNegative, Bits Ror never fails on negative shift.
+// It's safe to use on nil Bits, the Bits instance is returned as is.
+func (bits Bits) Ror(n int) Bits {
+	return bits.Rol(-n)
+}
+
+// OnesCount returns the number of one bits, population count, of the Bits instance.
+// It's safe to use on nil Bits, 0 is returned.
+func (bits Bits) OnesCount() int {
+	x := operand{bits: bits, blen: bits.BitLen()}
+	var n int
+	for k, words := 0, (x.blen+wsize-1)/wsize; k < words; k++ {
+		n += math_bits.OnesCount(x.word(k))
+	}
+	return n
+}
+
+// LeadingZeros returns the number of leading zero bits of the Bits instance relative to the bit length.
+// It's safe to use on nil Bits, 0 is returned.
+func (bits Bits) LeadingZeros() int {
+	return bits.BitLen() - bits.Len()
+}
+
+// TrailingZeros returns the number of trailing zero bits of the Bits instance,
+// the result is the bit length for empty Bits instance.
+// It's safe to use on nil Bits, 0 is returned.
+func (bits Bits) TrailingZeros() int {
+	x := operand{bits: bits, blen: bits.BitLen()}
+	for k, words := 0, (x.blen+wsize-1)/wsize; k < words; k++ {
+		if w := x.word(k); w != 0 {
+			return k*wsize + math_bits.TrailingZeros(w)
+		}
+	}
+	return x.blen
+}
+
+// Len returns the minimum number of bits required to represent the Bits instance value,
+// unlike BitLen it doesn't include leading zero bits.
+// It's safe to use on nil Bits, 0 is returned.
+func (bits Bits) Len() int {
+	x := operand{bits: bits, blen: bits.BitLen()}
+	for k := (x.blen+wsize-1)/wsize - 1; k >= 0; k-- {
+		if w := x.word(k); w != 0 {
+			return k*wsize + math_bits.Len(w)
+		}
+	}
+	return 0
+}
+
+// Bit returns the value of the k-th bit, either 0 or 1, of the Bits instance,
+// bits are counted from the least significant one.
+// It's safe to use on nil Bits or with out of bit length range k, 0 is returned.
+func (bits Bits) Bit(k int) uint {
+	blen := bits.BitLen()
+	if k < 0 || k >= blen {
+		return 0
+	}
+	return operand{bits: bits, blen: blen}.word(k/wsize) >> (k % wsize) & 1
+}
+
+// SetBit returns new Bits instance with the k-th bit set to the provided value,
+// bits are counted from the least significant one, any non zero value sets the bit to 1.
+// It's safe to use on nil Bits or with out of bit length range k, the Bits instance is returned as is.
+func (bits Bits) SetBit(k int, v uint) Bits {
+	if k < 0 || k >= bits.BitLen() {
+		return bits
+	}
+	// Deep copy always holds all the bit length words.
+	b := NewBitsBits(bits.BitLen(), bits)
+	if v != 0 {
+		b[k/wsize+1] |= 1 << (k % wsize)
+	} else {
+		b[k/wsize+1] &^= 1 << (k % wsize)
+	}
+	return b
+}
+
+// FlipBit returns new Bits instance with the k-th bit inverted,
+// bits are counted from the least significant one.
+// It's safe to use on nil Bits or with out of bit length range k, the Bits instance is returned as is.
+func (bits Bits) FlipBit(k int) Bits {
+	if k < 0 || k >= bits.BitLen() {
+		return bits
+	}
+	// Deep copy always holds all the bit length words.
+	b := NewBitsBits(bits.BitLen(), bits)
+	b[k/wsize+1] ^= 1 << (k % wsize)
+	return b
+}
+
 // Empty returns true on nil Bits, or if the bit length is 0
 // or if value bytes slice is empty, otherwise returns false.
 func (bits Bits) Empty() bool {
@@ -272,6 +413,19 @@ func (bits Bits) BigInt() *big.Int {
 	return i.SetBits(words)
 }
 
+// BigIntSigned allocates and returns a big.Int from value bytes slice of the Bits instance
+// interpreted as two's complement integer of the Bits bit length.
+// It's safe to use on nil Bits, 0 is returned.
+func (bits Bits) BigIntSigned() *big.Int {
+	i := bits.BigInt()
+	// In case the sign bit is set, subtract 2^blen
+	// to restore the negative number.
+	if blen := bits.BitLen(); blen > 0 && i.Bit(blen-1) == 1 {
+		i = i.Sub(i, big.NewInt(0).Lsh(big.NewInt(1), uint(blen)))
+	}
+	return i
+}
+
 // String returns a hex '%#X' string representation of the Bits instance
 // decorated with bit length, in format '[blen]{hex_bytes}'.
 // It's safe to use on nil Bits, [0]{0x0} is returned. Implements fmt.Stringer.
@@ -380,22 +534,15 @@ func (bits Bits) To(base int) []byte {
 	// Preallocate approximate resulting bytes.
 	r := make([]byte, 0, blen/base+1)
 	for run := true; run; {
-		// Start with division operation
-		// to advance to next digit.
-		_ = vint.Div(0, bs)
-		_ = vint.GetSet(0, b)
-		// Record the division result for the loop.
-		run = !b.Empty()
-		// Then take modulo from the value.
-		_ = vint.Mod(0, bs)
-		// Then take it value by swapping it with
-		// tmp variable, note then it's safe to get
+		// Start with combined division and modulo operation
+		// to advance to next digit, note then it's safe to get
 		// uint value directly here because modulo
 		// at most is equal to 62.
-		_ = vint.GetSet(0, b)
+		_ = vint.DivMod(0, bs, b)
 		r = append(r, b62digits[b.Uint()])
-		// Override original value back and continue iterating.
+		// Record the division result for the loop.
 		_ = vint.Get(0, b)
+		run = !b.Empty()
 	}
 	// Reverse the resulting bytes.
 	for i, j := 0, len(r)-1; i <= j; i, j = i+1, j-1 {
@@ -80,6 +80,58 @@ func TestBitsNew(t *testing.T) {
 			})
 		}
 	})
+	test("Signed", t, func(th h) {
+		table := map[string]struct {
+			blen int
+			big  *big.Int
+			bits Bits
+		}{
+			"nil big int should produce zero bits": {
+				blen: 8,
+				big:  nil,
+				bits: Bits{8, 0},
+			},
+			"nil big int should produce sign bit only bits on negative bit len": {
+				blen: -1,
+				big:  nil,
+				bits: Bits{1, 0},
+			},
+			"positive big int should produce non empty bits": {
+				blen: 8,
+				big:  big.NewInt(100),
+				bits: Bits{8, 100},
+			},
+			"negative big int should produce two's complement bits": {
+				blen: 8,
+				big:  big.NewInt(-100),
+				bits: Bits{8, 156},
+			},
+			"negative big int should produce two's complement bits with deduced bit len": {
+				blen: -1,
+				big:  big.NewInt(-128),
+				bits: Bits{8, 128},
+			},
+			"positive big int should produce bits with deduced bit len including sign bit": {
+				blen: -1,
+				big:  big.NewInt(128),
+				bits: Bits{9, 128},
+			},
+			"negative long big int should produce two's complement long bits": {
+				blen: 100,
+				big:  big.NewInt(-1),
+				bits: Bits{100, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFF},
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				bits := NewBitsBigIntSigned(tcase.blen, tcase.big)
+				h.Equal(tcase.bits, bits)
+				if tcase.big != nil {
+					h.Equal(tcase.big, bits.BigIntSigned())
+				}
+			})
+		}
+	})
 	test("String", t, func(th h) {
 		table := map[string]struct {
 			s    string
@@ -0,0 +1,579 @@
+package varint
+
+import math_bits "math/bits"
+
+// AddVarInt adds the integers inside the provided operand VarInt to the integers inside VarInt element-wise
+// and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case the addition result overflows the bit len for any integer, the regular unsigned semantic applies and
+// extra *RangeError warning wrapping ErrorAdditionOverflow with all the overflowed indexes is returned.
+func (vint VarInt) AddVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.addv)
+}
+
+// SubVarInt subtracts the integers inside the provided operand VarInt from the integers inside VarInt element-wise
+// and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case the subtraction result underflows any integer, the regular unsigned semantic applies and
+// extra *RangeError warning wrapping ErrorSubtractionUnderflow with all the underflowed indexes is returned.
+func (vint VarInt) SubVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.subv)
+}
+
+// MulVarInt multiplies the integers inside the provided operand VarInt with the integers inside VarInt element-wise
+// and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case the multiplication result overflows the bit len for any integer, the integer is truncated and
+// extra *RangeError warning wrapping ErrorMultiplicationOverflow with all the overflowed indexes is returned.
+func (vint VarInt) MulVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.mulv)
+}
+
+// DivVarInt divides the integers inside VarInt by the integers inside the provided operand VarInt element-wise
+// and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case the division by zero is attempted for any integer, the destination integer is left unchanged and
+// extra *RangeError wrapping ErrorDivisionByZero with all the zero divisor indexes is returned.
+func (vint VarInt) DivVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.divv)
+}
+
+// ModVarInt applies modulo operation to the integers inside VarInt and the integers inside the provided operand VarInt
+// element-wise and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case the division by zero is attempted for any integer, the destination integer is left unchanged and
+// extra *RangeError wrapping ErrorDivisionByZero with all the zero divisor indexes is returned.
+func (vint VarInt) ModVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.modv)
+}
+
+// NotVarInt applies bitwise negation ^ operation to the integers inside VarInt element-wise
+// and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place.
+// In case the operation is used on invalid nil VarInt or destination, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+func (vint VarInt) NotVarInt(dst VarInt) error {
+	// Use the VarInt itself as the operand,
+	// as the operand is never read by negation.
+	if err := vint.celem(vint, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vint, dst, VarInt.notv)
+}
+
+// AndVarInt applies bitwise and & operation to the integers inside VarInt and the integers inside the provided operand VarInt
+// element-wise and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+func (vint VarInt) AndVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.andv)
+}
+
+// OrVarInt applies bitwise or | operation to the integers inside VarInt and the integers inside the provided operand VarInt
+// element-wise and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+func (vint VarInt) OrVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.orv)
+}
+
+// XorVarInt applies bitwise xor ^ operation to the integers inside VarInt and the integers inside the provided operand VarInt
+// element-wise and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+func (vint VarInt) XorVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.xorv)
+}
+
+// RshVarInt applies right shift >> operation to the integers inside VarInt by the integers inside the provided operand VarInt
+// element-wise and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+func (vint VarInt) RshVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.rshv)
+}
+
+// LshVarInt applies left shift << operation to the integers inside VarInt by the integers inside the provided operand VarInt
+// element-wise and sets the results into the integers inside the provided destination VarInt at the same indexes.
+// The destination could be VarInt itself to apply the operation in place, but it can't be the operand VarInt.
+// In case the operation is used on invalid nil VarInt, operand or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination is the operand VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided VarInts have different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+func (vint VarInt) LshVarInt(vintx, dst VarInt) error {
+	if err := vint.celem(vintx, dst); err != nil {
+		return err
+	}
+	return vint.eachVarInt(vintx, dst, VarInt.lshv)
+}
+
+// celem internal helper that validates the provided
+// operand and destination VarInt against VarInt.
+func (vint VarInt) celem(vintx, dst VarInt) error {
+	// Check explicitly for invalid numbers.
+	if vint == nil || vintx == nil || dst == nil {
+		return ErrorVarIntIsInvalid
+	}
+	// Check that destination doesn't share the
+	// same numeric bytes slice with the operand.
+	if &dst[0] == &vintx[0] && &dst[0] != &vint[0] {
+		return ErrorVarIntIsInvalid
+	}
+	blen, length := BitLen(vint), Len(vint)
+	if BitLen(vintx) != blen || BitLen(dst) != blen {
+		return ErrorUnequalBitLengthCardinality
+	}
+	if Len(vintx) != length || Len(dst) != length {
+		return ErrorUnequalLengthCardinality
+	}
+	return nil
+}
+
+// eachVarInt internal helper that sequentially applies the provided element-wise
+// operation core to all the integers inside VarInt, operand and destination VarInt.
+// Each element-wise core sets the result of vint[i] op x into dst[i], where the
+// operand x for element-wise operations is always vintx integer at the same index.
+// It aggregates all the operation warnings into a single *RangeError report,
+// note that the operation is expected to return the same warning for all integers.
+func (vint VarInt) eachVarInt(vintx, dst VarInt, op func(VarInt, int, operand, VarInt) error) error {
+	var rerr *RangeError
+	for i, l := 0, Len(vint); i < l; i++ {
+		if err := op(vint, i, operand{vint: vintx, j: i}, dst); err != nil {
+			if rerr == nil {
+				rerr = &RangeError{Err: err}
+			}
+			rerr.Indexes = append(rerr.Indexes, i)
+		}
+	}
+	// Explicitly return nil interface
+	// instead of nil *RangeError.
+	if rerr == nil {
+		return nil
+	}
+	return rerr
+}
+
+// addv internal element-wise core of AddVarInt and AddAt operations.
+func (vint VarInt) addv(i int, x operand, dst VarInt) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Iterate from low to high word and add the words
+	// of both integers along with the carry flag, note
+	// that both words are read before the destination
+	// word is written, so in place operation is safe.
+	// In case of reject overflow policy, check for the overflow
+	// beforehand, so the destination integer is left unchanged.
+	if OverflowPolicy(dst) == OverflowReject && vint.addo(i, x) {
+		return ErrorAdditionOverflow
+	}
+	var carry, w uint
+	for k := 0; k < words; k++ {
+		w, carry = math_bits.Add(wget(vint, i, k), x.word(k), carry)
+		wset(dst, i, k, w)
+	}
+	// For partial high word the carry flag is never
+	// set, instead the overflow is inside excess bits.
+	if n := blen % wsize; n != 0 {
+		carry = w >> n
+	}
+	if carry > 0 {
+		return dst.overflow(i, ErrorAdditionOverflow)
+	}
+	return nil
+}
+
+// subv internal element-wise core of SubVarInt and SubAt operations.
+func (vint VarInt) subv(i int, x operand, dst VarInt) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Iterate from low to high word and subtract the words
+	// of both integers along with the borrow flag, note
+	// that both words are read before the destination
+	// word is written, so in place operation is safe.
+	// In case of reject overflow policy, check for the underflow
+	// beforehand, so the destination integer is left unchanged.
+	if OverflowPolicy(dst) == OverflowReject && vint.cmpv(i, x) < 0 {
+		return ErrorSubtractionUnderflow
+	}
+	var borrow, w uint
+	for k := 0; k < words; k++ {
+		w, borrow = math_bits.Sub(wget(vint, i, k), x.word(k), borrow)
+		wset(dst, i, k, w)
+	}
+	if borrow > 0 {
+		return dst.overflow(i, ErrorSubtractionUnderflow)
+	}
+	return nil
+}
+
+// mulv internal element-wise core of MulVarInt and MulAt operations.
+func (vint VarInt) mulv(i int, x operand, dst VarInt) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// For wide integers use Karatsuba multiplication with the
+	// full product calculated inside the collocated scratch words,
+	// otherwise use long multiplication inside tmp bits variable.
+	var bvarb []uint
+	var overflow bool
+	if kv := kvar(dst); kv != nil {
+		bvarb, overflow = vint.kmulv(i, x, kv)
+	} else {
+		bvarb = bvar(dst, true).Bytes()
+		// Iterate over words of both integers and multiply and
+		// accumulate the words into tmp bits variable, note that
+		// the destination integer is written only at the end,
+		// so in place operation is safe.
+		for a := 0; a < words; a++ {
+			wa := wget(vint, i, a)
+			if wa == 0 {
+				continue
+			}
+			var carry uint
+			for b := 0; b < words; b++ {
+				wb := x.word(b)
+				// If out of temp bits buffer is reached,
+				// any non zero product overflows the result.
+				w := a + b
+				if w >= words {
+					overflow = overflow || wb != 0
+					continue
+				}
+				var c1, c2 uint
+				hi, lo := math_bits.Mul(wa, wb)
+				lo, c1 = math_bits.Add(lo, carry, 0)
+				lo, c2 = math_bits.Add(lo, bvarb[w], 0)
+				bvarb[w] = lo
+				carry = hi + c1 + c2
+			}
+			overflow = overflow || carry != 0
+		}
+	}
+	// For partial high word check excess bits for the
+	// overflow, they are truncated by the word setter.
+	if n := blen % wsize; n != 0 {
+		overflow = overflow || bvarb[words-1]>>n != 0
+	}
+	// In case of reject overflow policy, the destination
+	// integer is left unchanged as it's written only here.
+	if overflow && OverflowPolicy(dst) == OverflowReject {
+		return ErrorMultiplicationOverflow
+	}
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, bvarb[k])
+	}
+	if overflow {
+		return dst.overflow(i, ErrorMultiplicationOverflow)
+	}
+	return nil
+}
+
+// divv internal element-wise core of DivVarInt and DivAt operations.
+func (vint VarInt) divv(i int, x operand, dst VarInt) error {
+	return vint.divmodv(i, x, dst, false)
+}
+
+// modv internal element-wise core of ModVarInt and ModAt operations.
+func (vint VarInt) modv(i int, x operand, dst VarInt) error {
+	return vint.divmodv(i, x, dst, true)
+}
+
+// divmodv internal element-wise core of DivVarInt, ModVarInt, DivAt and ModAt operations.
+// For modulo operation it only copies the remainder left by the division into the destination integer.
+func (vint VarInt) divmodv(i int, x operand, dst VarInt, mod bool) error {
+	if err := vint.divremv(i, x, dst, !mod); err != nil {
+		return err
+	}
+	if mod {
+		bvarb := bvar(dst, false).Bytes()
+		words := (BitLen(vint) + wsize - 1) / wsize
+		for k := 0; k < words; k++ {
+			wset(dst, i, k, bvarb[k])
+		}
+	}
+	return nil
+}
+
+// divremv internal division core of all division and modulo operations.
+// The quotient is written into the destination integer only if requested and
+// the remainder is always left inside destination tmp bits variable, see wdiv.
+func (vint VarInt) divremv(i int, x operand, dst VarInt, quo bool) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Check that the divisor is not zero,
+	// before the destination is changed.
+	zero := true
+	for k := 0; k < words && zero; k++ {
+		zero = x.word(k) == 0
+	}
+	if zero {
+		return ErrorDivisionByZero
+	}
+	bvarb := bvar(dst, true).Bytes()
+	// Special case, the divisor is the destination integer itself, so
+	// the quotient words would override the divisor, but it's always 1
+	// and the remainder is always 0.
+	if quo && x.vint != nil && x.j == i && &x.vint[0] == &dst[0] {
+		for k := 0; k < words; k++ {
+			wset(dst, i, k, 0)
+		}
+		wset(dst, i, 0, 1)
+		return nil
+	}
+	wdiv(operand{vint: vint, j: i}, x, operand{vint: dst, j: i}, bvarb[:words], quo)
+	return nil
+}
+
+// wdiv internal helper that divides the provided dividend operand by the provided non zero divisor operand
+// with word level long division method, Knuth Algorithm D. The partial remainder is kept inside the provided
+// remainder words and the divisor words are read directly from the operand, the number of the remainder words
+// defines the operands words. The quotient is written into the provided quotient operand only if requested.
+// The dividend is copied into the partial remainder before any quotient word is written, so the quotient
+// operand can be the dividend operand itself and in place operation is safe.
+func wdiv(u, v, q operand, r []uint, quo bool) {
+	words := len(r)
+	// Find the number of the divisor significant words.
+	n := words
+	for v.word(n-1) == 0 {
+		n--
+	}
+	// Fast path, the divisor is a single word, so every quotient word
+	// is produced by a single double word division and the remainder
+	// always fits into a single word.
+	if n == 1 {
+		d := v.word(0)
+		var qw, rw uint
+		for k := words - 1; k >= 0; k-- {
+			qw, rw = math_bits.Div(rw, u.word(k), d)
+			if quo {
+				q.set(k, qw)
+			}
+		}
+		for k := 1; k < words; k++ {
+			r[k] = 0
+		}
+		r[0] = rw
+		return
+	}
+	// Copy the dividend into the partial remainder R
+	// and find the number of its significant words.
+	m := 0
+	for k := 0; k < words; k++ {
+		r[k] = u.word(k)
+		if r[k] != 0 {
+			m = k + 1
+		}
+	}
+	// Instead of normalizing the operands copies, only two top words
+	// of the divisor and three top words of the partial remainder R
+	// are normalized on the fly to estimate each quotient word, while
+	// multiply and subtract steps work on the original operands.
+	s := uint(math_bits.LeadingZeros(v.word(n - 1)))
+	var w3 uint
+	if n > 2 {
+		w3 = v.word(n - 3)
+	}
+	v1, v2 := v.word(n-1)<<s|v.word(n-2)>>(wsize-s), v.word(n-2)<<s|w3>>(wsize-s)
+	for j := words - 1; j >= 0; j-- {
+		// All quotient words above the dividend
+		// significant words are always zero.
+		if j > m-n {
+			if quo {
+				q.set(j, 0)
+			}
+			continue
+		}
+		// Estimate the quotient word from the top words and correct
+		// the estimation, so it's either exact or greater by one.
+		u2, u1, u0 := wnorm(r, j+n, s), wnorm(r, j+n-1, s), wnorm(r, j+n-2, s)
+		qhat, rhat, c := ^uint(0), uint(0), uint(0)
+		if u2 < v1 {
+			qhat, rhat = math_bits.Div(u2, u1, v1)
+		} else {
+			rhat, c = math_bits.Add(u1, v1, 0)
+		}
+		for c == 0 {
+			if hi, lo := math_bits.Mul(qhat, v2); hi < rhat || hi == rhat && lo <= u0 {
+				break
+			}
+			qhat--
+			rhat, c = math_bits.Add(rhat, v1, 0)
+		}
+		// Multiply the divisor by the quotient word and subtract it
+		// from the partial remainder R, the top word might be outside
+		// of the remainder words, in which case it's implicitly zero.
+		var carry, borrow uint
+		for k := 0; k < n; k++ {
+			hi, lo := math_bits.Mul(qhat, v.word(k))
+			lo, c = math_bits.Add(lo, carry, 0)
+			carry = hi + c
+			r[j+k], borrow = math_bits.Sub(r[j+k], lo, borrow)
+		}
+		var top uint
+		if j+n < words {
+			top = r[j+n]
+		}
+		top, borrow = math_bits.Sub(top, carry, borrow)
+		// In the rare case the quotient word is still greater by one,
+		// the partial remainder R becomes negative, so add the divisor back.
+		if borrow != 0 {
+			qhat--
+			carry = 0
+			for k := 0; k < n; k++ {
+				r[j+k], carry = math_bits.Add(r[j+k], v.word(k), carry)
+			}
+			top += carry
+		}
+		if j+n < words {
+			r[j+n] = top
+		}
+		if quo {
+			q.set(j, qhat)
+		}
+	}
+}
+
+// wnorm internal helper that returns k-th word of the provided words shifted left by the provided shift,
+// the words outside of the provided words are treated as zero, so wnorm doesn't require any extra words.
+func wnorm(w []uint, k int, s uint) uint {
+	var hi, lo uint
+	if k >= 0 && k < len(w) {
+		hi = w[k]
+	}
+	if k > 0 && k <= len(w) {
+		lo = w[k-1]
+	}
+	return hi<<s | lo>>(wsize-s)
+}
+
+// notv internal element-wise core of NotVarInt operation.
+func (vint VarInt) notv(i int, _ operand, dst VarInt) error {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, ^wget(vint, i, k))
+	}
+	return nil
+}
+
+// andv internal element-wise core of AndVarInt and AndAt operations.
+func (vint VarInt) andv(i int, x operand, dst VarInt) error {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, wget(vint, i, k)&x.word(k))
+	}
+	return nil
+}
+
+// orv internal element-wise core of OrVarInt and OrAt operations.
+func (vint VarInt) orv(i int, x operand, dst VarInt) error {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, wget(vint, i, k)|x.word(k))
+	}
+	return nil
+}
+
+// xorv internal element-wise core of XorVarInt and XorAt operations.
+func (vint VarInt) xorv(i int, x operand, dst VarInt) error {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, wget(vint, i, k)^x.word(k))
+	}
+	return nil
+}
+
+// rshv internal element-wise core of RshVarInt and RshAt operations.
+func (vint VarInt) rshv(i int, x operand, dst VarInt) error {
+	n := vint.shiftv(i, x, dst)
+	_ = dst.rsh(i, n)
+	return nil
+}
+
+// lshv internal element-wise core of LshVarInt and LshAt operations.
+func (vint VarInt) lshv(i int, x operand, dst VarInt) error {
+	n := vint.shiftv(i, x, dst)
+	_ = dst.lsh(i, n)
+	return nil
+}
+
+// shiftv internal element-wise helper for shift operations.
+// It reads the shift from the operand integer, capped by the bit len,
+// and copies the integer into the destination integer to be shifted.
+func (vint VarInt) shiftv(i int, x operand, dst VarInt) int {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Any shift with non zero high words is
+	// greater than bit len, so cap it by bit len.
+	n := blen
+	if w := x.word(0); w < uint(blen) {
+		n = int(w)
+	}
+	for k := 1; k < words; k++ {
+		if x.word(k) != 0 {
+			n = blen
+		}
+	}
+	for k := 0; k < words; k++ {
+		wset(dst, i, k, wget(vint, i, k))
+	}
+	return n
+}
@@ -0,0 +1,192 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntElementWiseOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		vint := th.NewVarInt(len, len)
+		table := map[string]struct {
+			vint  VarInt
+			vintx VarInt
+			dst   VarInt
+			err   error
+			nerr  error
+		}{
+			"element-wise operations should return invalid varint error": {
+				vint:  nil,
+				vintx: th.NewVarInt(len, len),
+				dst:   th.NewVarInt(len, len),
+				err:   ErrorVarIntIsInvalid,
+				nerr:  ErrorVarIntIsInvalid,
+			},
+			"element-wise operations should return invalid varint error for nil destination": {
+				vint:  th.NewVarInt(len, len),
+				vintx: th.NewVarInt(len, len),
+				dst:   nil,
+				err:   ErrorVarIntIsInvalid,
+				nerr:  ErrorVarIntIsInvalid,
+			},
+			"element-wise operations should return invalid varint error for operand destination": {
+				vint:  th.NewVarInt(len, len),
+				vintx: vint,
+				dst:   vint,
+				err:   ErrorVarIntIsInvalid,
+			},
+			"element-wise operations should return bit len cardinarity error": {
+				vint:  th.NewVarInt(len, len),
+				vintx: th.NewVarInt(len, len),
+				dst:   th.NewVarInt(2*len, len),
+				err:   ErrorUnequalBitLengthCardinality,
+				nerr:  ErrorUnequalBitLengthCardinality,
+			},
+			"element-wise operations should return len cardinarity error": {
+				vint:  th.NewVarInt(len, len),
+				vintx: th.NewVarInt(len, 2*len),
+				dst:   th.NewVarInt(len, len),
+				err:   ErrorUnequalLengthCardinality,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(tcase.vint.AddVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.SubVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.MulVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.DivVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.ModVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.AndVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.OrVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.XorVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.RshVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.LshVarInt(tcase.vintx, tcase.dst), tcase.err)
+				h.Equal(tcase.vint.NotVarInt(tcase.dst), tcase.nerr)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply random element-wise operation either in place or into
+		// a separate destination and compare the results and reports
+		// with the same big.Int operations applied to each integer.
+		for k := 0; k < 200; k++ {
+			blen, l := []int{1, 7, 63, 64, 65, 100, 128, 129, 200}[rnd.Int()%9], rnd.Int()%20+1
+			vint, _ := NewVarInt(blen, l)
+			vintx, _ := NewVarInt(blen, l)
+			dst, _ := NewVarInt(blen, l)
+			for i := 0; i < l; i++ {
+				_ = vint.Set(i, NewBitsRand(blen, rnd))
+				_ = dst.Set(i, NewBitsRand(blen, rnd))
+				b := NewBitsRand(blen, rnd)
+				// Make sure small values and zeros are
+				// used as operands reasonably often.
+				switch rnd.Int() % 4 {
+				case 0:
+					b = NewBits(blen, nil)
+				case 1:
+					b = NewBits(blen, []uint{uint(rnd.Int() % (blen + 2))})
+				}
+				_ = vintx.Set(i, b)
+			}
+			if rnd.Int()%2 == 0 {
+				dst = vint
+			}
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			mask := new(big.Int).Sub(lim, big.NewInt(1))
+			op := rnd.Int() % 11
+			expected := make([]*big.Int, l)
+			var rerr *RangeError
+			for i := 0; i < l; i++ {
+				a, b := NewBits(blen, nil), NewBits(blen, nil)
+				_, _ = vint.Get(i, a), vintx.Get(i, b)
+				ab, bb, r := a.BigInt(), b.BigInt(), new(big.Int)
+				var err error
+				switch op {
+				case 0:
+					if r.Add(ab, bb); r.Cmp(lim) >= 0 {
+						err = ErrorAdditionOverflow
+					}
+				case 1:
+					if r.Sub(ab, bb); r.Sign() < 0 {
+						err = ErrorSubtractionUnderflow
+					}
+				case 2:
+					if r.Mul(ab, bb); r.Cmp(lim) >= 0 {
+						err = ErrorMultiplicationOverflow
+					}
+				case 3, 4:
+					switch {
+					case bb.Sign() == 0:
+						err = ErrorDivisionByZero
+						// Unchanged destination integer is expected.
+						d := NewBits(blen, nil)
+						_ = dst.Get(i, d)
+						r = d.BigInt()
+					case op == 3:
+						r.Quo(ab, bb)
+					default:
+						r.Rem(ab, bb)
+					}
+				case 5:
+					r.Not(ab)
+				case 6:
+					r.And(ab, bb)
+				case 7:
+					r.Or(ab, bb)
+				case 8:
+					r.Xor(ab, bb)
+				case 9:
+					if bb.IsUint64() && bb.Uint64() < uint64(blen) {
+						r.Rsh(ab, uint(bb.Uint64()))
+					}
+				default:
+					if bb.IsUint64() && bb.Uint64() < uint64(blen) {
+						r.Lsh(ab, uint(bb.Uint64()))
+					}
+				}
+				if err != nil {
+					if rerr == nil {
+						rerr = &RangeError{Err: err}
+					}
+					rerr.Indexes = append(rerr.Indexes, i)
+				}
+				expected[i] = r.And(r, mask)
+			}
+			var err error
+			switch op {
+			case 0:
+				err = vint.AddVarInt(vintx, dst)
+			case 1:
+				err = vint.SubVarInt(vintx, dst)
+			case 2:
+				err = vint.MulVarInt(vintx, dst)
+			case 3:
+				err = vint.DivVarInt(vintx, dst)
+			case 4:
+				err = vint.ModVarInt(vintx, dst)
+			case 5:
+				err = vint.NotVarInt(dst)
+			case 6:
+				err = vint.AndVarInt(vintx, dst)
+			case 7:
+				err = vint.OrVarInt(vintx, dst)
+			case 8:
+				err = vint.XorVarInt(vintx, dst)
+			case 9:
+				err = vint.RshVarInt(vintx, dst)
+			default:
+				err = vint.LshVarInt(vintx, dst)
+			}
+			if rerr == nil {
+				h.Equal(err, nil)
+			} else {
+				h.Equal(err, rerr)
+			}
+			h.VarInt = dst
+			for i := 0; i < l; i++ {
+				h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(expected[i])))
+			}
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package varint
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // The register of all static errors and warns that can be returned by VarInt.
 var (
@@ -8,14 +11,41 @@ var (
 	ErrorLengthIsNotPositive         = errors.New("the provided length has to be a strictly positive number")
 	ErrorBitLengthIsNotEfficient     = errors.New("the provided bit length is over the threshold, for efficiency consider decreasing it or use big.Int slice")
 	ErrorLengthIsNotEfficient        = errors.New("the provided length is under the threshold, for efficiency consider increasing it or use uint slice")
+	ErrorOverflowPolicyIsInvalid     = errors.New("the provided overflow policy is not valid")
+	ErrorModulusIsInvalid            = errors.New("the provided modulus has to be an odd number greater than 1 without leading zero bits")
 	ErrorVarIntIsInvalid             = errors.New("the varint is not valid for this operation")
 	ErrorIndexIsNegative             = errors.New("the provided index has to be not be a negative number")
 	ErrorIndexIsOutOfRange           = errors.New("the provided index is out of the number range")
+	ErrorLengthIsOutOfRange          = errors.New("the provided length is out of the number range")
 	ErrorUnequalBitLengthCardinality = errors.New("the provided bit length does not have equal cardinality with the number")
+	ErrorUnequalLengthCardinality    = errors.New("the provided length does not have equal cardinality with the number")
+	ErrorBitLengthIsTruncated        = errors.New("the provided bits value does not fit into the bit length and is truncated")
 	ErrorAdditionOverflow            = errors.New("the addition result overflows its max value")
 	ErrorMultiplicationOverflow      = errors.New("the multiplication result overflows its max value")
 	ErrorSubtractionUnderflow        = errors.New("the subtraction result underflow its min value")
 	ErrorDivisionByZero              = errors.New("the division result is undefined for 0 value divisor")
+	ErrorDivisionOverflow            = errors.New("the division result overflows its max value")
 	ErrorReaderIsNotDecodable        = errors.New("reader does not contain decodable bytes")
 	ErrorShiftIsNegative             = errors.New("the provided shift has to be not be a negative number")
+	ErrorDegreeIsNotPositive         = errors.New("the provided root degree has to be a strictly positive number")
+	ErrorModularInverseIsUndefined   = errors.New("the modular inverse is undefined for not coprime integer and modulus")
+	ErrorBitIndexIsOutOfRange        = errors.New("the provided bit index is out of the bit length range")
 )
+
+// RangeError is the aggregated report returned by VarInt operations applied to multiple integers at once.
+// It holds the static error or warn that occurred and all the integer indexes it occurred on in ascending order.
+// Note that RangeError wraps the static error, so it could be checked with errors.Is.
+type RangeError struct {
+	Err     error
+	Indexes []int
+}
+
+// Error returns the aggregated report message including all the failed integer indexes.
+func (err *RangeError) Error() string {
+	return fmt.Sprintf("%s, on integers at indexes %v", err.Err, err.Indexes)
+}
+
+// Unwrap returns the static error or warn that occurred.
+func (err *RangeError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,374 @@
+package varint
+
+import math_bits "math/bits"
+
+// GCD sets the integer inside VarInt at the provided index to the greatest common divisor of it and the provided bits.
+// It uses binary GCD method, Stein's algorithm, built on top of Rsh, Sub and GetSet operations, so it doesn't allocate.
+// Note that the greatest common divisor of any integer and 0 is the integer itself.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) GCD(i int, bits Bits) error {
+	if err := vint.cbits(i, bits); err != nil {
+		return err
+	}
+	vint.gcdv(i, operand{bits: bits, blen: BitLen(vint)})
+	return nil
+}
+
+// LCM sets the integer inside VarInt at the provided index to the least common multiple of it and the provided bits.
+// It divides the integer by the greatest common divisor first and then multiplies it with the provided bits,
+// the greatest common divisor is calculated the same way as in GCD. Note that the least common multiple
+// of any integer and 0 is 0. The intermediate state is kept either inside the collocated Karatsuba scratch
+// words for wide integers or inside a fixed size array on stack for narrow integers, so LCM doesn't allocate.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the least common multiple overflows the bit len, the integer is truncated and
+// extra ErrorMultiplicationOverflow warning is returned, unless other VarInt Overflow policy is used.
+func (vint VarInt) LCM(i int, bits Bits) error {
+	if err := vint.cbits(i, bits); err != nil {
+		return err
+	}
+	return vint.lcmv(i, operand{bits: bits, blen: BitLen(vint)})
+}
+
+// ModInverse sets the integer inside VarInt at the provided index to its multiplicative inverse modulo the provided mod bits.
+// It uses binary extended GCD method, and the intermediate state is kept either inside the collocated Karatsuba scratch
+// words for wide integers or inside a fixed size array on stack for narrow integers, so ModInverse doesn't allocate.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided mod bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the mod bits is zero, ErrorDivisionByZero is returned.
+// In case the integer and the mod bits are not coprime, the integer is left unchanged
+// and ErrorModularInverseIsUndefined is returned.
+func (vint VarInt) ModInverse(i int, mod Bits) error {
+	if err := vint.cbits(i, mod); err != nil {
+		return err
+	}
+	if mod.Empty() {
+		return ErrorDivisionByZero
+	}
+	return vint.inversev(i, operand{bits: mod, blen: BitLen(vint)})
+}
+
+// GCDAt sets the integer inside VarInt at the provided index i to the greatest common divisor
+// of it and the integer at the provided operand index j, see GCD for more details.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) GCDAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	vint.gcdv(i, operand{vint: vint, j: j})
+	return nil
+}
+
+// LCMAt sets the integer inside VarInt at the provided index i to the least common multiple
+// of it and the integer at the provided operand index j, see LCM for more details.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the least common multiple overflows the bit len, the integer is truncated and
+// extra ErrorMultiplicationOverflow warning is returned, unless other VarInt Overflow policy is used.
+func (vint VarInt) LCMAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	// The least common multiple of
+	// the integer and itself is itself.
+	if i == j {
+		return nil
+	}
+	return vint.lcmv(i, operand{vint: vint, j: j})
+}
+
+// ModInverseAt sets the integer inside VarInt at the provided index i to its multiplicative inverse
+// modulo the integer at the provided operand index j, see ModInverse for more details.
+// It reads the operand integer directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the operand integer is zero, ErrorDivisionByZero is returned.
+// In case the integer and the operand integer are not coprime, the integer is left unchanged
+// and ErrorModularInverseIsUndefined is returned.
+func (vint VarInt) ModInverseAt(i, j int) error {
+	if err := vint.cat(i, j); err != nil {
+		return err
+	}
+	if vint.tz(j) < 0 {
+		return ErrorDivisionByZero
+	}
+	return vint.inversev(i, operand{vint: vint, j: j})
+}
+
+// tz internal helper that returns the number of trailing zero bits
+// of the integer inside VarInt at the provided index, or -1 for zero integer.
+func (vint VarInt) tz(i int) int {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	for k := 0; k < words; k++ {
+		if w := wget(vint, i, k); w != 0 {
+			return k*wsize + math_bits.TrailingZeros(w)
+		}
+	}
+	return -1
+}
+
+// gcdv internal core of GCD and GCDAt operations, it copies the operand into tmp bits variable
+// and runs binary GCD method, Stein's algorithm, on the integer and tmp bits variable.
+func (vint VarInt) gcdv(i int, x operand) {
+	blen := BitLen(vint)
+	b := bvar(vint, true)
+	bb := b.Bytes()
+	for k := range bb {
+		bb[k] = x.word(k)
+	}
+	za, zb := vint.tz(i), wtz(bb)
+	switch {
+	case zb < 0:
+		return
+	case za < 0:
+		_ = vint.getset(i, b)
+		return
+	}
+	// Remember the common power of 2 of both integers.
+	shift := za
+	if zb < shift {
+		shift = zb
+	}
+	// Keep the odd integer inside tmp bits variable and reduce
+	// the other integer inside VarInt until it becomes zero,
+	// swapping them so the odd integer is always the smaller one.
+	_ = vint.rsh(i, za)
+	_ = vint.getset(i, b)
+	bo := operand{bits: b, blen: blen}
+	for z := zb; z >= 0; z = vint.tz(i) {
+		_ = vint.rsh(i, z)
+		if vint.cmpv(i, bo) < 0 {
+			_ = vint.getset(i, b)
+		}
+		_ = vint.subw(i, b)
+	}
+	_ = vint.getset(i, b)
+	_ = vint.lsh(i, shift)
+}
+
+// lcmv internal core of LCM and LCMAt operations, it calculates the least common multiple
+// as the integer divided by the greatest common divisor multiplied by the operand.
+func (vint VarInt) lcmv(i int, x operand) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Keep the integer copy inside the scratch words.
+	var stack [2*kthreshold + 1]uint
+	t := stack[:]
+	kv := kvar(vint)
+	if kv != nil {
+		t = kv
+	}
+	s := operand{bits: Bits(t[:words+1]), blen: blen}
+	sb := s.bits[1:]
+	for k := 0; k < words; k++ {
+		sb[k] = wget(vint, i, k)
+	}
+	vint.gcdv(i, x)
+	// The greatest common divisor is zero
+	// only if both integers are zero.
+	if vint.tz(i) < 0 {
+		return nil
+	}
+	// Divide the integer copy by the greatest common divisor,
+	// then swap them, so the greatest common divisor is kept.
+	wdiv(s, operand{vint: vint, j: i}, s, bvar(vint, false).Bytes(), true)
+	for k := 0; k < words; k++ {
+		w := wget(vint, i, k)
+		wset(vint, i, k, sb[k])
+		sb[k] = w
+	}
+	// Multiplication of wide integers uses the scratch words,
+	// so move the greatest common divisor into tmp bits variable.
+	if kv != nil {
+		b := bvar(vint, false)
+		copy(b.Bytes(), sb)
+		s.bits = b
+	}
+	err := vint.mulv(i, x, vint)
+	// In case of reject overflow policy, restore the original
+	// integer by multiplying it back by the greatest common divisor.
+	if err != nil && OverflowPolicy(vint) == OverflowReject {
+		_ = vint.mulv(i, s, vint)
+	}
+	return err
+}
+
+// inversev internal core of ModInverse and ModInverseAt operations, the operand is expected to be not zero.
+// For odd modulus m, it runs binary extended GCD method on the integer a reduced modulo m and m directly.
+// For even modulus m, the inverse exists only for odd a, so it calculates y = m^-1 mod a instead and
+// then x = (1 + m*(a-y))/a, which is exact division by odd integer that is calculated low words first.
+func (vint VarInt) inversev(i int, x operand) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Keep all the intermediate state inside the scratch words.
+	var stack [5 * 2 * kthreshold]uint
+	t := stack[:]
+	if kv := kvar(vint); kv != nil {
+		t = kv
+	}
+	m, as, v, c, y := t[:words], t[words:2*words], t[2*words:3*words], t[3*words:4*words], t[4*words:5*words]
+	u := bvar(vint, true).Bytes()
+	for k := 0; k < words; k++ {
+		m[k], as[k] = x.word(k), wget(vint, i, k)
+	}
+	a := operand{vint: vint, j: i}
+	if m[0]&1 == 1 {
+		wdiv(a, x, operand{}, u, false)
+		copy(v, m)
+		if !winv(y, u, v, c, m) {
+			return ErrorModularInverseIsUndefined
+		}
+	} else {
+		if as[0]&1 == 0 {
+			return ErrorModularInverseIsUndefined
+		}
+		// The inverse of 1 is always 1 itself.
+		if as[0] == 1 && wtz(as[1:]) < 0 {
+			return nil
+		}
+		wdiv(x, a, operand{}, u, false)
+		copy(v, as)
+		if !winv(y, u, v, c, as) {
+			return ErrorModularInverseIsUndefined
+		}
+		// Calculate 1 + m*(a-y) truncated to the bit len words,
+		// as the exact quotient always fits into the bit len.
+		copy(v, as)
+		_ = wsub(v, y)
+		wmullo(c, v, m)
+		_ = wadd(c, c, []uint{1})
+		wexact(y, c, as)
+	}
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, y[k])
+	}
+	return nil
+}
+
+// wtz internal helper that returns the number of trailing
+// zero bits of the provided words, or -1 for zero words.
+func wtz(x []uint) int {
+	for k, w := range x {
+		if w != 0 {
+			return k*wsize + math_bits.TrailingZeros(w)
+		}
+	}
+	return -1
+}
+
+// wcmp internal helper that compares the provided equal length words x and y.
+func wcmp(x, y []uint) int {
+	for k := len(x) - 1; k >= 0; k-- {
+		switch {
+		case x[k] < y[k]:
+			return -1
+		case x[k] > y[k]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// whalf internal helper that halves the provided words x in place. In case the modulus m
+// is provided and x is odd, m is added to x first, so x is halved modulo odd m exactly.
+func whalf(x, m []uint) {
+	var carry uint
+	if m != nil && x[0]&1 == 1 {
+		carry = wadd(x, x, m)
+	}
+	n := len(x) - 1
+	for k := 0; k < n; k++ {
+		x[k] = x[k]>>1 | x[k+1]<<(wsize-1)
+	}
+	x[n] = x[n]>>1 | carry<<(wsize-1)
+}
+
+// winv internal helper that sets the provided words x to the inverse modulo the provided odd modulus m
+// with binary extended GCD method, the provided words u and v are expected to be the integer reduced modulo m
+// and m itself respectively, the provided words c are used for the integer coefficient. All of u, v, c are
+// destroyed. It returns false if the inverse doesn't exist, as the greatest common divisor is not 1.
+func winv(x, u, v, c, m []uint) bool {
+	// Keep the invariants c*a = u and x*a = v modulo m.
+	for k := range x {
+		x[k], c[k] = 0, 0
+	}
+	c[0] = 1
+	for wtz(u) >= 0 {
+		for u[0]&1 == 0 {
+			whalf(u, nil)
+			whalf(c, m)
+		}
+		for v[0]&1 == 0 {
+			whalf(v, nil)
+			whalf(x, m)
+		}
+		if wcmp(u, v) >= 0 {
+			_ = wsub(u, v)
+			if wsub(c, x) != 0 {
+				_ = wadd(c, c, m)
+			}
+		} else {
+			_ = wsub(v, u)
+			if wsub(x, c) != 0 {
+				_ = wadd(x, x, m)
+			}
+		}
+	}
+	// The greatest common divisor is left inside v.
+	return v[0] == 1 && wtz(v[1:]) < 0
+}
+
+// wmullo internal helper that sets the low words of the product of the provided words x and y
+// into the provided words z, the product is truncated to the same length as all words have.
+func wmullo(z, x, y []uint) {
+	for k := range z {
+		z[k] = 0
+	}
+	for a, wa := range x {
+		var carry uint
+		for b := 0; a+b < len(z); b++ {
+			var c1, c2 uint
+			hi, lo := math_bits.Mul(wa, y[b])
+			lo, c1 = math_bits.Add(lo, carry, 0)
+			z[a+b], c2 = math_bits.Add(lo, z[a+b], 0)
+			carry = hi + c1 + c2
+		}
+	}
+}
+
+// wexact internal helper that sets the exact quotient of the provided words n divided by the provided
+// odd words d into the provided words q, the quotient is truncated to the same length as all words have.
+// The quotient words are calculated from the low word with d^-1 mod word, and n is destroyed.
+func wexact(q, n, d []uint) {
+	// Calculate d^-1 mod word with Newton iterations,
+	// odd integer is its own inverse mod 8 and each
+	// iteration doubles the number of correct low bits.
+	inv := d[0]
+	for k := 0; k < 6; k++ {
+		inv *= 2 - d[0]*inv
+	}
+	for k := range q {
+		q[k] = n[k] * inv
+		var carry, borrow uint
+		for b := 0; k+b < len(n); b++ {
+			var c uint
+			hi, lo := math_bits.Mul(q[k], d[b])
+			lo, c = math_bits.Add(lo, carry, 0)
+			carry = hi + c
+			n[k+b], borrow = math_bits.Sub(n[k+b], lo, borrow)
+		}
+	}
+}
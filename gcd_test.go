@@ -0,0 +1,194 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntGCDOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i    int
+			j    int
+			bits Bits
+			err  error
+		}{
+			"gcd operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				j:    2,
+				bits: NewBits(len, []uint{3}),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"gcd operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    -1,
+				j:    2,
+				bits: NewBits(len, []uint{3}),
+				err:  ErrorIndexIsNegative,
+			},
+			"gcd operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				j:    2,
+				bits: NewBits(len, []uint{3}),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"gcd operations should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				j:    2,
+				bits: NewBits(2*len, []uint{3}),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+			"modular inverse operations should return zero division error on division by zero": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				j:    2,
+				bits: NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
+			"modular inverse operations should return undefined inverse error on not coprime integers": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				j:    2,
+				bits: NewBits(len, []uint{6}),
+				err:  ErrorModularInverseIsUndefined,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				if tcase.vint != nil {
+					_ = tcase.vint.Set(1, NewBits(len, []uint{4}))
+					_ = tcase.vint.Set(2, tcase.bits)
+				}
+				switch tcase.err {
+				case ErrorDivisionByZero, ErrorModularInverseIsUndefined:
+					h.NoError(tcase.vint.GCD(tcase.i, tcase.bits))
+					h.NoError(tcase.vint.LCM(tcase.i, tcase.bits))
+				default:
+					h.Equal(tcase.vint.GCD(tcase.i, tcase.bits), tcase.err)
+					h.Equal(tcase.vint.LCM(tcase.i, tcase.bits), tcase.err)
+				}
+				h.Equal(tcase.vint.ModInverse(tcase.i, tcase.bits), tcase.err)
+				// Index only operations don't have any bits to validate.
+				if tcase.err != ErrorUnequalBitLengthCardinality {
+					if tcase.vint != nil {
+						_ = tcase.vint.Set(1, NewBits(len, []uint{4}))
+					}
+					h.Equal(tcase.vint.ModInverseAt(tcase.i, tcase.j), tcase.err)
+				}
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply GCD, LCM and ModInverse in both bits and index forms
+		// and compare the result and the warning with the same big.Int
+		// operations for all overflow policies.
+		for k := 0; k < 1000; k++ {
+			blen, l := []int{1, 7, 63, 64, 65, 128, 129, 200, 521, 2 * kthreshold * wsize}[rnd.Int()%10], rnd.Int()%4+2
+			i, j := rnd.Int()%l, rnd.Int()%l
+			overflow := Overflow(rnd.Int() % 3)
+			vint, _ := NewVarIntOverflow(blen, l, overflow)
+			h.VarInt = vint
+			for x := 0; x < l; x++ {
+				h.VarIntSet(x, NewBitsRand(blen, rnd))
+			}
+			// Make sure integers with common factors, small
+			// integers and zero integers are checked as well.
+			switch rnd.Int() % 4 {
+			case 0:
+				f := NewBitsRand(blen/2+1, rnd).BigInt()
+				for _, x := range []int{i, j} {
+					b := new(big.Int).Mul(f, NewBitsRand(blen/2+1, rnd).BigInt())
+					b.Lsh(b, uint(rnd.Int()%5))
+					if b.BitLen() <= blen {
+						h.VarIntSet(x, NewBitsBits(blen, NewBitsBigInt(b)))
+					}
+				}
+			case 1:
+				h.VarIntSet(j, NewBitsBits(blen, NewBitsUint(uint(rnd.Int()%3))))
+			}
+			a, b := h.VarIntGet(i).BigInt(), h.VarIntGet(j).BigInt()
+			bits, at := h.VarIntGet(j), rnd.Int()%2 == 0
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			switch rnd.Int() % 3 {
+			case 0:
+				r := new(big.Int).GCD(nil, nil, a, b)
+				if at {
+					h.NoError(vint.GCDAt(i, j))
+				} else {
+					h.NoError(vint.GCD(i, bits))
+				}
+				h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r)))
+			case 1:
+				r := new(big.Int)
+				if g := new(big.Int).GCD(nil, nil, a, b); g.Sign() != 0 {
+					r.Mul(r.Div(a, g), b)
+				}
+				var expected error
+				if r.Cmp(lim) >= 0 {
+					expected = ErrorMultiplicationOverflow
+					switch overflow {
+					case OverflowSaturate:
+						r.Sub(lim, big.NewInt(1))
+					case OverflowReject:
+						r.Set(a)
+					}
+				}
+				if at {
+					if i == j {
+						r, expected = a, nil
+					}
+					h.Equal(vint.LCMAt(i, j), expected)
+				} else {
+					h.Equal(vint.LCM(i, bits), expected)
+				}
+				h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.Mod(r, lim))))
+			case 2:
+				if b.Sign() == 0 {
+					continue
+				}
+				r := new(big.Int)
+				var expected error
+				if g := new(big.Int).GCD(nil, nil, a, b); g.Cmp(big.NewInt(1)) != 0 {
+					r, expected = a, ErrorModularInverseIsUndefined
+				} else if b.Cmp(big.NewInt(1)) != 0 {
+					r.ModInverse(a, b)
+				}
+				if at {
+					if i == j {
+						// The integer is coprime to itself only if it's 1.
+						r, expected = a, ErrorModularInverseIsUndefined
+						if a.Cmp(big.NewInt(1)) == 0 {
+							r, expected = big.NewInt(0), nil
+						}
+					}
+					h.Equal(vint.ModInverseAt(i, j), expected)
+				} else {
+					h.Equal(vint.ModInverse(i, bits), expected)
+				}
+				h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r)))
+			}
+		}
+	})
+	test("Allocs", t, func(h h) {
+		// Operations on both narrow and wide
+		// integers don't allocate any new memory.
+		for _, blen := range []int{100, 2 * kthreshold * wsize} {
+			vint, bits, mod := h.NewVarInt(blen, len), NewBitsRand(blen, rnd), NewBitsRand(blen, rnd)
+			mod.Bytes()[0] |= 1
+			allocs := testing.AllocsPerRun(10, func() {
+				_ = vint.Set(1, bits)
+				_ = vint.GCD(1, mod)
+				_ = vint.Set(1, bits)
+				_ = vint.LCM(1, mod)
+				_ = vint.Set(1, bits)
+				_ = vint.ModInverse(1, mod)
+			})
+			h.Equal(allocs, 0.0)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package varint
+
+// Append appends the provided bits to the end of VarInt and returns the updated VarInt, akin to builtin append.
+// In case the underlying numeric bytes slice capacity is not enough to fit the appended integers,
+// it is reallocated with amortized growth, so the returned VarInt always has to be used instead of the original one.
+// The extra state of VarInt converted from SVarInt or MVarInt is preserved, so it could be converted back.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case any of the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned
+// and the original VarInt is returned unchanged.
+func (vint VarInt) Append(bits ...Bits) (VarInt, error) {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return nil, ErrorVarIntIsInvalid
+	}
+	blen, l := BitLen(vint), Len(vint)
+	// Check all the bits before growing the number.
+	for _, b := range bits {
+		if blenx := b.BitLen(); blenx != blen {
+			return vint, ErrorUnequalBitLengthCardinality
+		}
+	}
+	vint = resize(vint, l+len(bits))
+	for i, b := range bits {
+		_ = vint.Set(l+i, b)
+	}
+	return vint, nil
+}
+
+// Insert inserts the provided bits into VarInt at the provided index and returns the updated VarInt,
+// shifting the integer at the provided index and all the following integers to the right.
+// In case the underlying numeric bytes slice capacity is not enough to fit the inserted integer,
+// it is reallocated with amortized growth, so the returned VarInt always has to be used instead of the original one.
+// The extra state of VarInt converted from SVarInt or MVarInt is preserved, so it could be converted back.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case of any error the original VarInt is returned unchanged.
+func (vint VarInt) Insert(i int, bits Bits) (VarInt, error) {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return nil, ErrorVarIntIsInvalid
+	}
+	// Check that non negative index was provided.
+	if i < 0 {
+		return vint, ErrorIndexIsNegative
+	}
+	// Check that requested index is inside varint range,
+	// note that inserting right after the last integer is allowed.
+	l := Len(vint)
+	if i > l {
+		return vint, ErrorIndexIsOutOfRange
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return vint, ErrorUnequalBitLengthCardinality
+	}
+	vint = resize(vint, l+1)
+	// Move all the integers after the index one by one
+	// from the back, using temp bits variable as a buffer.
+	bvar := bvar(vint, false)
+	for j := l - 1; j >= i; j-- {
+		_ = vint.Get(j, bvar)
+		_ = vint.Set(j+1, bvar)
+	}
+	_ = vint.Set(i, bits)
+	return vint, nil
+}
+
+// Delete deletes the provided number of integers from VarInt starting from the provided index
+// and returns the updated VarInt, shifting all the following integers to the left.
+// It never reallocates the underlying numeric bytes slice, but the returned VarInt
+// still always has to be used instead of the original one.
+// The extra state of VarInt converted from SVarInt or MVarInt is preserved, so it could be converted back.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided number of integers is not positive, ErrorLengthIsNotPositive is returned.
+// In case the provided index plus the number of integers is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case all the integers are deleted, ErrorLengthIsNotPositive is returned as VarInt can't be empty.
+// In case of any error the original VarInt is returned unchanged.
+func (vint VarInt) Delete(i, n int) (VarInt, error) {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return nil, ErrorVarIntIsInvalid
+	}
+	// Check that non negative index was provided.
+	if i < 0 {
+		return vint, ErrorIndexIsNegative
+	}
+	// Check that positive number of integers was provided.
+	if n <= 0 {
+		return vint, ErrorLengthIsNotPositive
+	}
+	// Check that requested range is inside varint range.
+	l := Len(vint)
+	if i+n > l {
+		return vint, ErrorIndexIsOutOfRange
+	}
+	if l-n <= 0 {
+		return vint, ErrorLengthIsNotPositive
+	}
+	// Move all the integers after the deleted range one by one
+	// from the front, using temp bits variable as a buffer.
+	bvar := bvar(vint, false)
+	for j := i + n; j < l; j++ {
+		_ = vint.Get(j, bvar)
+		_ = vint.Set(j-n, bvar)
+	}
+	return resize(vint, l-n), nil
+}
+
+// Resize changes len of VarInt to the provided len and returns the updated VarInt.
+// In case the provided len is greater than len of VarInt, new zero integers are added to the end,
+// otherwise the integers past the provided len are dropped.
+// In case the underlying numeric bytes slice capacity is not enough to fit the provided len,
+// it is reallocated with amortized growth, so the returned VarInt always has to be used instead of the original one.
+// The extra state of VarInt converted from SVarInt or MVarInt is preserved, so it could be converted back.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided len is not positive, ErrorLengthIsNotPositive is returned.
+// In case of any error the original VarInt is returned unchanged.
+func (vint VarInt) Resize(len int) (VarInt, error) {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return nil, ErrorVarIntIsInvalid
+	}
+	if len <= 0 {
+		return vint, ErrorLengthIsNotPositive
+	}
+	return resize(vint, len), nil
+}
+
+// Truncate drops all the integers past the provided len from VarInt and returns the updated VarInt.
+// It never reallocates the underlying numeric bytes slice, but the returned VarInt
+// still always has to be used instead of the original one.
+// The extra state of VarInt converted from SVarInt or MVarInt is preserved, so it could be converted back.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided len is not positive, ErrorLengthIsNotPositive is returned.
+// In case the provided len is greater than len of VarInt, ErrorLengthIsOutOfRange is returned.
+// In case of any error the original VarInt is returned unchanged.
+func (vint VarInt) Truncate(len int) (VarInt, error) {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return nil, ErrorVarIntIsInvalid
+	}
+	if len <= 0 {
+		return vint, ErrorLengthIsNotPositive
+	}
+	if len > Len(vint) {
+		return vint, ErrorLengthIsOutOfRange
+	}
+	return resize(vint, len), nil
+}
+
+// resize internal helper that changes len of the provided VarInt to the provided len.
+// It grows the underlying numeric bytes slice with amortized growth if needed,
+// clears all the bits past the last integer and moves temp bits variable
+// right after the last integer to keep VarInt layout valid. Any extra state
+// collocated after the multiplication scratch words, like SVarInt extra Bits
+// variable or MVarInt modulus and constants, is moved to the very end as is.
+func resize(vint VarInt, l int) VarInt {
+	blen, lprev := BitLen(vint), Len(vint)
+	// Calculate capacity to fit all integers with
+	// provided bit length and the new length.
+	// Calculate number of whole words plus
+	// one word if partial mod word is needed.
+	vcap := (blen*l+wsize-1)/wsize + 2
+	words := blen/wsize + (blen%wsize+wsize-1)/wsize
+	end := vcap + words + 1 + kwords(blen)
+	// Calculate the extra state words collocated after
+	// the previous layout, they are preserved as is.
+	pend := (blen*lprev+wsize-1)/wsize + 2 + words + 1 + kwords(blen)
+	ext := len(vint) - pend
+	size := end + ext
+	if size > cap(vint) {
+		// Double the capacity akin to builtin append,
+		// to amortize the cost of consequent growths.
+		grow := 2 * cap(vint)
+		if grow < size {
+			grow = size
+		}
+		nvint := make(VarInt, size, grow)
+		copy(nvint, vint)
+		vint = nvint
+	}
+	vint = vint[:size]
+	// Move the extra state before clearing, note that
+	// copy handles the overlapping words correctly.
+	copy(vint[end:], vint[pend:pend+ext])
+	// Clear all the bits starting from the end of the last
+	// integer that is kept, this includes stale bits inside
+	// the last partial word and the previous temp bits variable.
+	bto := blen*lprev + wsize*2
+	if l < lprev {
+		bto = blen*l + wsize*2
+	}
+	k := bto / wsize
+	if bshift := bto % wsize; bshift != 0 {
+		vint[k] = vint[k] >> (wsize - bshift) << (wsize - bshift)
+		k++
+	}
+	for ; k < end; k++ {
+		vint[k] = 0
+	}
+	vint[0] = uint(l)
+	// Restore temp bits variable header
+	// right after the last integer.
+	vint[vcap] = uint(blen)
+	return vint
+}
@@ -0,0 +1,209 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGrow(t *testing.T) {
+	test("Errors", t, func(th h) {
+		const len = 10
+		table := map[string]struct {
+			vint VarInt
+			op   func(vint VarInt) (VarInt, error)
+			err  error
+		}{
+			"append should return invalid varint error": {
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Append(NewBits(len, nil))
+				},
+				err: ErrorVarIntIsInvalid,
+			},
+			"append should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Append(NewBits(len, nil), NewBits(2*len, nil))
+				},
+				err: ErrorUnequalBitLengthCardinality,
+			},
+			"insert should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Insert(-1, NewBits(len, nil))
+				},
+				err: ErrorIndexIsNegative,
+			},
+			"insert should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Insert(len+1, NewBits(len, nil))
+				},
+				err: ErrorIndexIsOutOfRange,
+			},
+			"insert should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Insert(1, NewBits(2*len, nil))
+				},
+				err: ErrorUnequalBitLengthCardinality,
+			},
+			"delete should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Delete(-1, 1)
+				},
+				err: ErrorIndexIsNegative,
+			},
+			"delete should return not positive length error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Delete(1, 0)
+				},
+				err: ErrorLengthIsNotPositive,
+			},
+			"delete should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Delete(len-1, 2)
+				},
+				err: ErrorIndexIsOutOfRange,
+			},
+			"delete should return not positive length error for all integers": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Delete(0, len)
+				},
+				err: ErrorLengthIsNotPositive,
+			},
+			"resize should return not positive length error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Resize(0)
+				},
+				err: ErrorLengthIsNotPositive,
+			},
+			"truncate should return not positive length error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Truncate(-1)
+				},
+				err: ErrorLengthIsNotPositive,
+			},
+			"truncate should return length is out of range error": {
+				vint: th.NewVarInt(len, len),
+				op: func(vint VarInt) (VarInt, error) {
+					return vint.Truncate(len + 1)
+				},
+				err: ErrorLengthIsOutOfRange,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				vint, err := tcase.op(tcase.vint)
+				h.Equal(err, tcase.err)
+				h.Equal(vint, tcase.vint)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Grow a varint from a single integer by appending,
+		// inserting, deleting and resizing random integers while
+		// tracking the same operations on a bits slice. After each
+		// step verify that all integers match the bits slice and
+		// that the varint layout matches a freshly allocated varint.
+		const steps = 200
+		blen := rnd.Int()%150 + 1
+		vint := h.NewVarInt(blen, 1)
+		bits := []Bits{NewBits(blen, nil)}
+		var err error
+		for s := 0; s < steps; s++ {
+			l := len(bits)
+			switch op := rnd.Int() % 6; {
+			case op < 2:
+				b1, b2 := NewBitsRand(blen, rnd), NewBitsRand(blen, rnd)
+				vint, err = vint.Append(b1, b2)
+				bits = append(bits, b1, b2)
+			case op == 2:
+				i, b := rnd.Int()%(l+1), NewBitsRand(blen, rnd)
+				vint, err = vint.Insert(i, b)
+				bits = append(bits[:i], append([]Bits{b}, bits[i:]...)...)
+			case op == 3 && l > 1:
+				i := rnd.Int() % (l - 1)
+				n := rnd.Int()%(l-i-1) + 1
+				vint, err = vint.Delete(i, n)
+				bits = append(bits[:i], bits[i+n:]...)
+			case op == 4:
+				n := rnd.Int()%(l+10) + 1
+				vint, err = vint.Resize(n)
+				for len(bits) < n {
+					bits = append(bits, NewBits(blen, nil))
+				}
+				bits = bits[:n]
+			default:
+				n := rnd.Int()%l + 1
+				vint, err = vint.Truncate(n)
+				bits = bits[:n]
+			}
+			h.NoError(err)
+			h.VarInt = vint
+			h.Equal(Len(vint), len(bits))
+			vintc, _ := NewVarInt(blen, len(bits))
+			for i, b := range bits {
+				h.VarIntEqual(i, b)
+				_ = vintc.Set(i, b)
+			}
+			// Temp bits variable might still hold the last moved
+			// integer, so clear it before comparing the layout.
+			_ = bvar(vint, true)
+			h.Equal(vint, vintc)
+		}
+	})
+	test("Converted", t, func(h h) {
+		// Grow and shrink varints converted from signed and modular varints
+		// by the same random operations and verify that their extra state
+		// collocated at the very end is preserved, then convert the modular
+		// varint back and compare its multiplication with big.Int.
+		for k := 0; k < 100; k++ {
+			blen := rnd.Int()%150 + 2
+			words := (blen + wsize - 1) / wsize
+			mod := NewBitsRand(blen, rnd).SetBit(0, 1).SetBit(blen-1, 1)
+			svint, _ := NewSVarInt(blen, 1)
+			mvint, _ := NewMVarInt(mod, 1)
+			sext := append([]uint(nil), svint[len(svint)-words-1:]...)
+			mext := append([]uint(nil), mvint[len(mvint)-3*words-5:]...)
+			vints := []VarInt{VarInt(svint), VarInt(mvint)}
+			for s := 0; s < 20; s++ {
+				l := Len(vints[0])
+				op, b := rnd.Int()%4, NewBitsRand(blen, rnd)
+				b = NewBitsBits(blen, NewBitsBigInt(new(big.Int).Mod(b.BigInt(), mod.BigInt())))
+				n := rnd.Int()%(l+10) + 1
+				for x, vint := range vints {
+					var err error
+					switch {
+					case op == 0:
+						vint, err = vint.Append(b, b)
+					case op == 1:
+						vint, err = vint.Insert(l, b)
+					case op == 2 && l > 1:
+						vint, err = vint.Delete(0, 1)
+					default:
+						vint, err = vint.Resize(n)
+					}
+					h.NoError(err)
+					vints[x] = vint
+				}
+			}
+			vs, vm := vints[0], vints[1]
+			h.Equal([]uint(vs[len(vs)-len(sext):]), sext)
+			h.Equal([]uint(vm[len(vm)-len(mext):]), mext)
+			mvint = MVarInt(vm)
+			i := rnd.Int() % Len(vm)
+			a, b := NewBitsRand(blen, rnd), NewBitsRand(blen, rnd)
+			h.NoError(mvint.Set(i, a))
+			h.NoError(mvint.Mul(i, b))
+			r := new(big.Int).Mul(a.BigInt(), b.BigInt())
+			h.VarInt = vm
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.Mod(r, mod.BigInt()))))
+		}
+	})
+}
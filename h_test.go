@@ -101,6 +101,19 @@ func (h *h) NewVarInt(bits, length int) VarInt {
 	return vint
 }
 
+func (h *h) NewSVarInt(bits, length int) SVarInt {
+	h.Helper()
+	svint, err := NewSVarInt(bits, length)
+	// Ignore known len warnings.
+	if err != nil &&
+		err != ErrorBitLengthIsNotEfficient &&
+		err != ErrorLengthIsNotEfficient {
+		h.Fatal(err)
+	}
+	h.VarInt = VarInt(svint)
+	return svint
+}
+
 func (h h) VarIntGet(i int) Bits {
 	h.Helper()
 	b := NewBits(BitLen(h.VarInt), nil)
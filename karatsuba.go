@@ -0,0 +1,146 @@
+package varint
+
+import math_bits "math/bits"
+
+// kthreshold const number of words starting from which
+// Karatsuba multiplication is used instead of long multiplication.
+const kthreshold = 40
+
+// kwords internal helper that returns number of extra scratch words collocated on VarInt
+// for Karatsuba multiplication of the provided bit len. The scratch is reserved only
+// for bit lengths that are wide enough to benefit from at least one Karatsuba recursion.
+// It includes both operands words, full product words and the recursion scratch words.
+func kwords(blen int) int {
+	words := (blen + wsize - 1) / wsize
+	if words < 2*kthreshold {
+		return 0
+	}
+	return 4*words + kscratch(words)
+}
+
+// kscratch internal helper that returns number of recursion scratch
+// words required by Karatsuba multiplication of the provided words number.
+func kscratch(n int) int {
+	if n < kthreshold {
+		return 0
+	}
+	n1 := n - n/2 + 1
+	return 4*n1 + kscratch(n1)
+}
+
+// kvar internal accessor that returns reserved Karatsuba multiplication scratch words.
+// The scratch words are collocated on VarInt right after the temp bits variable,
+// so kvar doesn't allocate any new memory. For narrow bit lengths, nil is returned.
+func kvar(vint VarInt) []uint {
+	blen := BitLen(vint)
+	kw := kwords(blen)
+	if kw == 0 {
+		return nil
+	}
+	cap := (blen*Len(vint)+wsize-1)/wsize + 2
+	words := (blen + wsize - 1) / wsize
+	return vint[cap+words+1 : cap+words+1+kw]
+}
+
+// kmul internal helper that sets the full product of the provided equal length
+// words x and y into the provided words z of double length. For the words number
+// above the threshold it uses Karatsuba multiplication recursively with the provided
+// scratch words, otherwise it falls back to long multiplication.
+func kmul(z, x, y, t []uint) {
+	n := len(x)
+	if n < kthreshold {
+		lmul(z, x, y)
+		return
+	}
+	// Split both operands into low and high halves,
+	// x = x1*B+x0 and y = y1*B+y0, then z is calculated as
+	// z2*B^2 + ((x0+x1)*(y0+y1)-z2-z0)*B + z0.
+	n0 := n / 2
+	n1 := n - n0
+	x0, x1, y0, y1 := x[:n0], x[n0:], y[:n0], y[n0:]
+	kmul(z[:2*n0], x0, y0, t)
+	kmul(z[2*n0:], x1, y1, t)
+	// Calculate both halves sums with an extra carry word
+	// and their product inside the scratch words.
+	xs, ys, zs, t := t[:n1+1], t[n1+1:2*n1+2], t[2*n1+2:4*n1+4], t[4*n1+4:]
+	wadd(xs, x1, x0)
+	wadd(ys, y1, y0)
+	kmul(zs, xs, ys, t)
+	// Subtract both z0 and z2 from the middle product,
+	// the result is never negative, then add it shifted.
+	wsub(zs, z[:2*n0])
+	wsub(zs, z[2*n0:])
+	wadd(z[n0:], z[n0:], zs)
+}
+
+// lmul internal helper that sets the full product of the provided words x and y
+// into the provided words z of double length with long multiplication.
+func lmul(z, x, y []uint) {
+	for k := range z {
+		z[k] = 0
+	}
+	for a, wa := range x {
+		if wa == 0 {
+			continue
+		}
+		var carry uint
+		for b, wb := range y {
+			var c1, c2 uint
+			hi, lo := math_bits.Mul(wa, wb)
+			lo, c1 = math_bits.Add(lo, carry, 0)
+			lo, c2 = math_bits.Add(lo, z[a+b], 0)
+			z[a+b] = lo
+			carry = hi + c1 + c2
+		}
+		z[a+len(y)] = carry
+	}
+}
+
+// wadd internal helper that sets the sum of the provided words x and y into
+// the provided words z, where x and z have at least the same length as y.
+// The carry is propagated through all z words, the last carry is returned.
+func wadd(z, x, y []uint) uint {
+	var carry uint
+	for k := range z {
+		var wx, wy uint
+		if k < len(x) {
+			wx = x[k]
+		}
+		if k < len(y) {
+			wy = y[k]
+		}
+		z[k], carry = math_bits.Add(wx, wy, carry)
+	}
+	return carry
+}
+
+// wsub internal helper that subtracts the provided words y from the provided words z in place.
+// The borrow is propagated through all z words, the last borrow is returned.
+func wsub(z, y []uint) uint {
+	var borrow uint
+	for k := range z {
+		var wy uint
+		if k < len(y) {
+			wy = y[k]
+		}
+		z[k], borrow = math_bits.Sub(z[k], wy, borrow)
+	}
+	return borrow
+}
+
+// kmulv internal helper that multiplies the integer inside VarInt at the provided index
+// with the provided operand using the provided Karatsuba scratch words. It returns
+// the low product words that fit into the bit len words and true if any high product word is set.
+func (vint VarInt) kmulv(i int, x operand, kv []uint) ([]uint, bool) {
+	words := (BitLen(vint) + wsize - 1) / wsize
+	xs, ys, zs, t := kv[:words], kv[words:2*words], kv[2*words:4*words], kv[4*words:]
+	for k := 0; k < words; k++ {
+		xs[k], ys[k] = wget(vint, i, k), x.word(k)
+	}
+	kmul(zs, xs, ys, t)
+	var overflow bool
+	for k := words; k < 2*words; k++ {
+		overflow = overflow || zs[k] != 0
+	}
+	return zs[:words], overflow
+}
@@ -0,0 +1,78 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestKaratsuba(t *testing.T) {
+	test("Words", t, func(h h) {
+		// Karatsuba multiplication has to yield exactly
+		// the same full product as long multiplication
+		// including the words with all the bits set.
+		for n := 1; n < 6*kthreshold; n += 7 {
+			x, y := make([]uint, n), make([]uint, n)
+			for k := 0; k < n; k++ {
+				x[k], y[k] = uint(rnd.Uint64()), uint(rnd.Uint64())
+				if n%3 == 0 {
+					x[k], y[k] = ^uint(0), ^uint(0)
+				}
+			}
+			z, zl := make([]uint, 2*n), make([]uint, 2*n)
+			kmul(z, x, y, make([]uint, kscratch(n)))
+			lmul(zl, x, y)
+			h.Equal(z, zl)
+		}
+	})
+	test("Layout", t, func(h h) {
+		// Scratch words are reserved only for wide integers.
+		h.Equal(kvar(h.NewVarInt(5000, 10)) == nil, true)
+		blen := 2 * kthreshold * wsize
+		vint := h.NewVarInt(blen, 10)
+		h.Equal(len(kvar(vint)), kwords(blen))
+		// Growth has to keep the reserved scratch words.
+		vint, _ = vint.Append(NewBits(blen, nil))
+		h.Equal(len(kvar(vint)), kwords(blen))
+	})
+	test("Rand", t, func(h h) {
+		// Apply Mul and its counterparts to wide integers
+		// and compare the result and the warning with
+		// the same big.Int operation.
+		for k := 0; k < 50; k++ {
+			blen, l := 2*kthreshold*wsize+rnd.Int()%(4*kthreshold*wsize), rnd.Int()%4+1
+			i := rnd.Int() % l
+			vint := h.NewVarInt(blen, l)
+			for x := 0; x < l; x++ {
+				_ = vint.Set(x, NewBitsRand(blen, rnd))
+			}
+			// Make sure both overflowed and not
+			// overflowed products are checked.
+			bits := NewBitsRand(blen, rnd)
+			if rnd.Int()%2 == 0 {
+				_ = vint.Set(i, NewBitsBits(blen, NewBitsRand(blen/2, rnd)))
+				bits = NewBitsBits(blen, NewBitsRand(blen/2-1, rnd))
+			}
+			ai := h.VarIntGet(i)
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			r := new(big.Int).Mul(ai.BigInt(), bits.BigInt())
+			var expected error
+			if r.Cmp(lim) >= 0 {
+				expected = ErrorMultiplicationOverflow
+			}
+			var err error
+			switch rnd.Int() % 3 {
+			case 0:
+				err = vint.Mul(i, bits)
+			case 1:
+				err = vint.MulAny(i, bits)
+			default:
+				err = vint.MulSat(i, bits)
+				if expected != nil {
+					r.Sub(lim, big.NewInt(1))
+				}
+			}
+			h.Equal(err, expected)
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.Mod(r, lim))))
+		}
+	})
+}
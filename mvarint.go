@@ -0,0 +1,388 @@
+package varint
+
+import math_bits "math/bits"
+
+// MVarInt provides fast and memory efficient arbitrary bit length modular integer array type.
+//
+// MVarInt shares the memory layout of VarInt and stores all the integers inside as
+// residues modulo the shared modulus provided on creation, so each integer is always kept reduced
+// in range [0, modulus-1]. Get is shared with VarInt, while Set, GetSet and all the arithmetic
+// operations reduce the result modulo the modulus. Mul uses Montgomery multiplication with word level
+// reduction instead of slow division, and the integers are converted in and out of Montgomery form on the fly.
+// For chains of multiplications the integers could also be kept in Montgomery form explicitly with
+// ToMontgomery, MulMontgomery and FromMontgomery, note that Add and Sub are valid for both forms.
+// On top of VarInt layout, MVarInt also collocates the modulus, precomputed Montgomery constants
+// and extra temp buffer at the very end of numeric bytes slice which are used internally by the operations.
+// Any MVarInt could be converted to VarInt at no cost to reinterpret the integers as unsigned, note however
+// that the opposite conversion is not valid as VarInt doesn't reserve the extra space.
+// Currently, for simplicity and consistency most MVarInt operations apply changes in place on the provided index
+// and require the provided Bits to have exactly the same bit len, otherwise ErrorUnequalBitLengthCardinality is returned.
+// The provided Bits are not required to be reduced, however.
+type MVarInt []uint
+
+// NewMVarInt allocates and returns MVarInt instance that is capable to fit the provided number
+// of modular integers each of the provided modulus bit len in width.
+// In case the provided modulus is not an odd number greater than 1 or has leading zero bits,
+// invalid number and ErrorModulusIsInvalid is returned.
+// Otherwise, it follows exactly the same rules and returns exactly the same errors and warnings as NewVarInt.
+// See MVarInt type for more details.
+func NewMVarInt(modulus Bits, len int) (MVarInt, error) {
+	blen := modulus.BitLen()
+	// Check that the modulus is odd for Montgomery reduction
+	// and that its most significant bit is set, so any integer of
+	// the bit len is less than double modulus.
+	if blen < 2 || modulus[1]&1 == 0 || modulus[(blen-1)/wsize+1]>>((blen-1)%wsize)&1 == 0 {
+		return nil, ErrorModulusIsInvalid
+	}
+	vint, err := NewVarInt(blen, len)
+	if vint == nil {
+		return nil, err
+	}
+	// Allocate extra protected space at the back for the modulus,
+	// R^2 mod modulus constant, -modulus^-1 mod word constant and
+	// for Montgomery multiplication temp buffer of two extra words.
+	words := (blen + wsize - 1) / wsize
+	ext := make([]uint, 3*words+5)
+	n, rr, t := Bits(ext[:words+1]), Bits(ext[words+1:2*words+2]), ext[2*words+3:]
+	n[0], rr[0] = uint(blen), uint(blen)
+	copy(n.Bytes(), modulus.Bytes())
+	nb := n.Bytes()
+	// Calculate -modulus^-1 mod word constant.
+	ext[2*words+2] = mneg(nb[0])
+	// Calculate R^2 mod modulus, where R is 2^(words*wsize),
+	// by doubling 1 with reduction 2*words*wsize times.
+	t[0] = 1
+	for k := 0; k < 2*words*wsize; k++ {
+		var carry uint
+		for x := 0; x <= words; x++ {
+			carry, t[x] = t[x]>>(wsize-1), t[x]<<1|carry
+		}
+		mreduce(t[:words+1], nb)
+	}
+	copy(rr.Bytes(), t[:words])
+	mvint := MVarInt(append(vint, ext...))
+	return mvint, err
+}
+
+// Get sets the provided bits to the integer inside MVarInt at the provided index.
+// See VarInt Get for more details.
+func (mvint MVarInt) Get(i int, bits Bits) error {
+	return VarInt(mvint).Get(i, bits)
+}
+
+// Set sets the provided bits reduced modulo the modulus into the integer inside MVarInt at the provided index.
+// See VarInt Set for more details.
+func (mvint MVarInt) Set(i int, bits Bits) error {
+	if err := mvint.check(i, bits); err != nil {
+		return err
+	}
+	_ = VarInt(mvint).set(i, bits)
+	mvint.reduce(i)
+	return nil
+}
+
+// GetSet swaps the provided bits reduced modulo the modulus with the integer inside MVarInt at the provided index.
+// See VarInt GetSet for more details.
+func (mvint MVarInt) GetSet(i int, bits Bits) error {
+	if err := mvint.check(i, bits); err != nil {
+		return err
+	}
+	_ = VarInt(mvint).getset(i, bits)
+	mvint.reduce(i)
+	return nil
+}
+
+// Add adds the provided bits to the integer inside MVarInt at the provided index modulo the modulus.
+// In case the operation is used on invalid nil MVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of MVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (mvint MVarInt) Add(i int, bits Bits) error {
+	if err := mvint.check(i, bits); err != nil {
+		return err
+	}
+	vint := VarInt(mvint)
+	n, _, _, t := mvar(mvint)
+	nb, bitsb := n.Bytes(), bits.Bytes()
+	words := len(nb)
+	// Add the words of both integers into temp buffer
+	// with an extra word for the carry flag.
+	var carry uint
+	for k := 0; k < words; k++ {
+		t[k], carry = math_bits.Add(wget(vint, i, k), bitsb[k], carry)
+	}
+	t[words] = carry
+	// The sum of reduced integer and any integer of the bit len
+	// is less than triple modulus, so two reductions are enough.
+	mreduce(t[:words+1], nb)
+	mreduce(t[:words+1], nb)
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, t[k])
+	}
+	return nil
+}
+
+// Sub subtracts the provided bits from the integer inside MVarInt at the provided index modulo the modulus.
+// In case the operation is used on invalid nil MVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of MVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (mvint MVarInt) Sub(i int, bits Bits) error {
+	if err := mvint.check(i, bits); err != nil {
+		return err
+	}
+	vint := VarInt(mvint)
+	n, _, _, t := mvar(mvint)
+	nb := n.Bytes()
+	words := len(nb)
+	// Reduce the provided bits first inside temp buffer,
+	// then subtract them from the integer and in case of
+	// the underflow add the modulus back, the high carry
+	// is discarded as the words wrap around by design.
+	copy(t, bits.Bytes())
+	t[words] = 0
+	mreduce(t[:words+1], nb)
+	var borrow uint
+	for k := 0; k < words; k++ {
+		t[k], borrow = math_bits.Sub(wget(vint, i, k), t[k], borrow)
+	}
+	if borrow > 0 {
+		var carry uint
+		for k := 0; k < words; k++ {
+			t[k], carry = math_bits.Add(t[k], nb[k], carry)
+		}
+	}
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, t[k])
+	}
+	return nil
+}
+
+// Mul multiplies the provided bits with the integer inside MVarInt at the provided index modulo the modulus.
+// It uses two Montgomery multiplications, the first one yields the product in Montgomery form divided by R,
+// and the second one with precomputed R^2 constant converts the product back from Montgomery form.
+// In case the operation is used on invalid nil MVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of MVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (mvint MVarInt) Mul(i int, bits Bits) error {
+	if err := mvint.check(i, bits); err != nil {
+		return err
+	}
+	_, rr, _, _ := mvar(mvint)
+	mvint.montmul(i, mvint.reduced(bits))
+	mvint.montmul(i, rr.Bytes())
+	return nil
+}
+
+// ToMontgomery converts the integer inside MVarInt at the provided index into Montgomery form.
+// In case the operation is used on invalid nil MVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of MVarInt, ErrorIndexIsOutOfRange is returned.
+func (mvint MVarInt) ToMontgomery(i int) error {
+	if err := VarInt(mvint).cany(i); err != nil {
+		return err
+	}
+	_, rr, _, _ := mvar(mvint)
+	mvint.montmul(i, rr.Bytes())
+	return nil
+}
+
+// FromMontgomery converts the integer inside MVarInt at the provided index back from Montgomery form.
+// In case the operation is used on invalid nil MVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of MVarInt, ErrorIndexIsOutOfRange is returned.
+func (mvint MVarInt) FromMontgomery(i int) error {
+	if err := VarInt(mvint).cany(i); err != nil {
+		return err
+	}
+	one := bvar(VarInt(mvint), true)
+	one[1] = 1
+	mvint.montmul(i, one.Bytes())
+	return nil
+}
+
+// MulMontgomery multiplies the provided bits in Montgomery form with the integer inside MVarInt
+// in Montgomery form at the provided index modulo the modulus, keeping the result in Montgomery form.
+// In case the operation is used on invalid nil MVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of MVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (mvint MVarInt) MulMontgomery(i int, bits Bits) error {
+	if err := mvint.check(i, bits); err != nil {
+		return err
+	}
+	mvint.montmul(i, mvint.reduced(bits))
+	return nil
+}
+
+// check internal validator that verifies that the provided index
+// and bits are valid for MVarInt operations with bits operand.
+// The operations without any operand validate only the index with cany.
+func (mvint MVarInt) check(i int, bits Bits) error {
+	return VarInt(mvint).cbits(i, bits)
+}
+
+// reduce internal helper that reduces the integer inside MVarInt at the provided index
+// which is expected to be less than double modulus, so a single reduction is enough.
+func (mvint MVarInt) reduce(i int) {
+	vint := VarInt(mvint)
+	n, _, _, t := mvar(mvint)
+	nb := n.Bytes()
+	words := len(nb)
+	for k := 0; k < words; k++ {
+		t[k] = wget(vint, i, k)
+	}
+	t[words] = 0
+	mreduce(t[:words+1], nb)
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, t[k])
+	}
+}
+
+// reduced internal helper that copies the provided bits reduced
+// modulo the modulus into VarInt temp bits variable and returns its words.
+func (mvint MVarInt) reduced(bits Bits) []uint {
+	n, _, _, t := mvar(mvint)
+	nb := n.Bytes()
+	words := len(nb)
+	copy(t, bits.Bytes())
+	t[words] = 0
+	mreduce(t[:words+1], nb)
+	b := bvar(VarInt(mvint), false).Bytes()
+	copy(b, t[:words])
+	return b
+}
+
+// montmul internal helper that applies Montgomery multiplication to the integer inside MVarInt
+// at the provided index and the provided reduced words, i.e. sets a*b*R^-1 mod modulus.
+// The integer words are read only once and the integer is written only at the end.
+func (mvint MVarInt) montmul(i int, b []uint) {
+	vint := VarInt(mvint)
+	n, _, n0, t := mvar(mvint)
+	nb := n.Bytes()
+	mmul(t, operand{vint: vint, j: i}, b, nb, n0)
+	for k := 0; k < len(nb); k++ {
+		wset(vint, i, k, t[k])
+	}
+}
+
+// mvar internal accessor that returns reserved modulus, R^2 mod modulus and -modulus^-1 mod word
+// constants along with Montgomery multiplication temp buffer. They are collocated at the very end
+// of MVarInt after VarInt temp bits variable, so mvar doesn't allocate any new memory.
+func mvar(mvint MVarInt) (n, rr Bits, n0 uint, t []uint) {
+	blen := BitLen(VarInt(mvint))
+	words := (blen + wsize - 1) / wsize
+	l := len(mvint)
+	return Bits(mvint[l-3*words-5 : l-2*words-4]),
+		Bits(mvint[l-2*words-4 : l-words-3]),
+		mvint[l-words-3],
+		mvint[l-words-2:]
+}
+
+// mreduce internal helper that subtracts the provided modulus words from the provided
+// words in place once, only if the words are greater or equal to the modulus.
+func mreduce(t []uint, nb []uint) {
+	// Compare the words with the modulus from high to low word,
+	// the extra high words are compared with zero.
+	ge := true
+	for k := len(t) - 1; k >= 0; k-- {
+		var w uint
+		if k < len(nb) {
+			w = nb[k]
+		}
+		if t[k] != w {
+			ge = t[k] > w
+			break
+		}
+	}
+	if !ge {
+		return
+	}
+	var borrow uint
+	for k := 0; k < len(t); k++ {
+		var w uint
+		if k < len(nb) {
+			w = nb[k]
+		}
+		t[k], borrow = math_bits.Sub(t[k], w, borrow)
+	}
+}
+
+// mmul internal helper that applies Montgomery multiplication to the provided operand and the provided
+// words reduced modulo the provided odd modulus words, i.e. sets a*b*R^-1 mod modulus into the provided
+// temp buffer of two extra words. It uses coarsely integrated operand scanning method, the operand words
+// are read only once, and it's shared by MVarInt multiplications and VarInt ModPow.
+func mmul(t []uint, a operand, b []uint, nb []uint, n0 uint) {
+	words := len(nb)
+	for k := range t {
+		t[k] = 0
+	}
+	for x := 0; x < words; x++ {
+		// Multiply and accumulate the operand word
+		// with the provided words into temp buffer.
+		ax := a.word(x)
+		var c, c1, c2, hi, lo uint
+		for y := 0; y < words; y++ {
+			hi, lo = math_bits.Mul(b[y], ax)
+			lo, c1 = math_bits.Add(lo, t[y], 0)
+			lo, c2 = math_bits.Add(lo, c, 0)
+			t[y], c = lo, hi+c1+c2
+		}
+		t[words], c = math_bits.Add(t[words], c, 0)
+		t[words+1] = c
+		// Add the modulus multiple that zeroes the lowest
+		// temp word and shift the temp buffer by one word.
+		m := t[0] * n0
+		hi, lo = math_bits.Mul(m, nb[0])
+		_, c1 = math_bits.Add(lo, t[0], 0)
+		c = hi + c1
+		for y := 1; y < words; y++ {
+			hi, lo = math_bits.Mul(m, nb[y])
+			lo, c1 = math_bits.Add(lo, t[y], 0)
+			lo, c2 = math_bits.Add(lo, c, 0)
+			t[y-1], c = lo, hi+c1+c2
+		}
+		t[words-1], c = math_bits.Add(t[words], c, 0)
+		t[words] = t[words+1] + c
+	}
+	// The result is less than double modulus,
+	// so a single reduction is enough.
+	mreduce(t[:words+1], nb)
+}
+
+// mform internal helper that sets the provided operand converted into Montgomery form, i.e. a*R mod modulus,
+// into the provided temp buffer of at least one extra word. The operand bits up to its bit len are reduced modulo
+// the provided modulus words one by one and then doubled modulo the modulus once per each R bit, so the modulus
+// could have any leading zero bits and R^2 mod modulus constant isn't required.
+func mform(t []uint, a operand, nb []uint) {
+	words := len(nb)
+	for k := range t {
+		t[k] = 0
+	}
+	for b := a.blen + words*wsize - 1; b >= 0; b-- {
+		// Double the reduced words and add the next
+		// operand bit, the result is less than double modulus,
+		// so a single reduction is enough.
+		var carry uint
+		if b >= words*wsize {
+			x := b - words*wsize
+			carry = a.word(x/wsize) >> (x % wsize) & 1
+		}
+		for k := 0; k <= words; k++ {
+			carry, t[k] = t[k]>>(wsize-1), t[k]<<1|carry
+		}
+		mreduce(t[:words+1], nb)
+	}
+}
+
+// mneg internal helper that returns -n^-1 mod word for the provided odd word n
+// with Newton iterations, odd word is its own inverse mod 8 and each iteration
+// doubles the number of correct low bits.
+func mneg(n uint) uint {
+	inv := n
+	for k := 0; k < 6; k++ {
+		inv *= 2 - n*inv
+	}
+	return -inv
+}
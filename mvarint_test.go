@@ -0,0 +1,190 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMVarIntNew(t *testing.T) {
+	table := map[string]struct {
+		modulus Bits
+		len     int
+		err     error
+	}{
+		"empty modulus should resolve in expected error": {
+			modulus: NewBits(0, nil),
+			len:     10,
+			err:     ErrorModulusIsInvalid,
+		},
+		"one modulus should resolve in expected error": {
+			modulus: NewBits(1, []uint{1}),
+			len:     10,
+			err:     ErrorModulusIsInvalid,
+		},
+		"even modulus should resolve in expected error": {
+			modulus: NewBits(10, []uint{1000}),
+			len:     10,
+			err:     ErrorModulusIsInvalid,
+		},
+		"modulus with leading zero bits should resolve in expected error": {
+			modulus: NewBits(100, []uint{1001}),
+			len:     10,
+			err:     ErrorModulusIsInvalid,
+		},
+		"negative len should resolve in expected error": {
+			modulus: NewBitsUint(1001),
+			len:     -1,
+			err:     ErrorLengthIsNotPositive,
+		},
+		"small len should resolve in valid mvint but warning": {
+			modulus: NewBitsUint(1001),
+			len:     3,
+			err:     ErrorLengthIsNotEfficient,
+		},
+		"odd modulus and len resolve in valid mvint": {
+			modulus: NewBitsString("3rNk68AgS73raYcuFFPjD3MPzU5ELtIwjHVcu", 62),
+			len:     10,
+		},
+	}
+	for tname, tcase := range table {
+		test(tname, t, func(h h) {
+			mvint, err := NewMVarInt(tcase.modulus, tcase.len)
+			h.NoError(tcase.err, err)
+			if mvint == nil {
+				return
+			}
+			h.Equal(Len(VarInt(mvint)), tcase.len)
+			h.Equal(BitLen(VarInt(mvint)), tcase.modulus.BitLen())
+			n, _, _, _ := mvar(mvint)
+			h.Equal(n, tcase.modulus)
+		})
+	}
+}
+
+func TestMVarIntOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		mvint, _ := NewMVarInt(NewBitsUint(1001), len)
+		wide, _ := NewMVarInt(NewBits(128, []uint{1, 1 << (wsize - 1)}), len)
+		table := map[string]struct {
+			mvint MVarInt
+			i     int
+			bits  Bits
+			err   error
+		}{
+			"modular operations should return invalid varint error": {
+				mvint: nil,
+				i:     1,
+				bits:  NewBits(10, nil),
+				err:   ErrorVarIntIsInvalid,
+			},
+			"modular operations should return negative index error": {
+				mvint: mvint,
+				i:     -1,
+				bits:  NewBits(10, nil),
+				err:   ErrorIndexIsNegative,
+			},
+			"modular operations should return index is out of range error": {
+				mvint: mvint,
+				i:     len,
+				bits:  NewBits(10, nil),
+				err:   ErrorIndexIsOutOfRange,
+			},
+			"modular operations should return bit len cardinarity error": {
+				mvint: mvint,
+				i:     1,
+				bits:  NewBits(20, nil),
+				err:   ErrorUnequalBitLengthCardinality,
+			},
+			"modular operations should return bit len cardinarity error on nil bits": {
+				mvint: wide,
+				i:     1,
+				bits:  nil,
+				err:   ErrorUnequalBitLengthCardinality,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(tcase.mvint.Set(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.mvint.GetSet(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.mvint.Add(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.mvint.Sub(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.mvint.Mul(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.mvint.MulMontgomery(tcase.i, tcase.bits), tcase.err)
+				if tcase.err == ErrorUnequalBitLengthCardinality {
+					return
+				}
+				h.Equal(tcase.mvint.ToMontgomery(tcase.i), tcase.err)
+				h.Equal(tcase.mvint.FromMontgomery(tcase.i), tcase.err)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply random modular operation and compare
+		// the result with the same big.Int operation
+		// reduced modulo the same random modulus.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{2, 7, 63, 64, 65, 100, 128, 129, 256, 521}[rnd.Int()%10], rnd.Int()%10+1
+			modulus := NewBitsRand(blen, rnd)
+			modulus[1] |= 1
+			modulus[(blen-1)/wsize+1] |= 1 << ((blen - 1) % wsize)
+			mvint, _ := NewMVarInt(modulus, l)
+			h.VarInt = VarInt(mvint)
+			m := modulus.BigInt()
+			for x := 0; x < l; x++ {
+				h.NoError(mvint.Set(x, NewBitsRand(blen, rnd)))
+			}
+			i := rnd.Int() % l
+			ai := h.VarIntGet(i)
+			a := ai.BigInt()
+			// Make sure all the integers are kept reduced.
+			h.Equal(a.Cmp(m) < 0, true)
+			bits := NewBitsRand(blen, rnd)
+			b, r := bits.BigInt(), new(big.Int)
+			rinv := new(big.Int).Lsh(big.NewInt(1), uint((blen+wsize-1)/wsize*wsize))
+			rinv.ModInverse(rinv, m)
+			switch rnd.Int() % 8 {
+			case 0:
+				r.Mod(b, m)
+				h.NoError(mvint.Set(i, bits))
+			case 1:
+				r.Mod(b, m)
+				h.NoError(mvint.GetSet(i, bits))
+				h.Equal(bits, ai)
+			case 2:
+				r.Mod(r.Add(a, b), m)
+				h.NoError(mvint.Add(i, bits))
+			case 3:
+				r.Mod(r.Sub(a, b), m)
+				h.NoError(mvint.Sub(i, bits))
+			case 4:
+				r.Mod(r.Mul(a, b), m)
+				h.NoError(mvint.Mul(i, bits))
+			case 5:
+				r.Mod(r.Mul(a, b), m)
+				r.Mod(r.Mul(r, rinv), m)
+				h.NoError(mvint.MulMontgomery(i, bits))
+			case 6:
+				// Conversion in and out of Montgomery
+				// form has to be an identity operation.
+				r.Set(a)
+				h.NoError(mvint.ToMontgomery(i))
+				h.NoError(mvint.FromMontgomery(i))
+			default:
+				// The product of two integers in Montgomery
+				// form has to be a product in Montgomery form.
+				r.Mod(r.Mul(a, b), m)
+				h.NoError(mvint.ToMontgomery(i))
+				j := (i + 1) % l
+				c := h.VarIntGet(j)
+				h.NoError(mvint.Set(j, bits))
+				h.NoError(mvint.ToMontgomery(j))
+				bm := h.VarIntGet(j)
+				h.VarIntSet(j, c)
+				h.NoError(mvint.MulMontgomery(i, bm))
+				h.NoError(mvint.FromMontgomery(i))
+			}
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r)))
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package varint
+
+import math_bits "math/bits"
+
+// obits const number of high bits of VarInt bit length header
+// reserved to store VarInt overflow policy.
+const obits = 2
+
+// Overflow defines the policy VarInt applies to the integer when arithmetic
+// operation result doesn't fit into the bit len, i.e. on ErrorAdditionOverflow,
+// ErrorSubtractionUnderflow and ErrorMultiplicationOverflow.
+// The policy is chosen once on VarInt creation, see NewVarIntOverflow,
+// and is honored consistently by all the arithmetic operations that write
+// the result into VarInt integers: Add, Sub, Mul, Pow and LCM including
+// range, element-wise, index-to-index and any counterparts, PrefixSum and Difference.
+// For element-wise operations, PrefixSum and Difference the policy of the destination VarInt is used.
+// AddSat, SubSat and MulSat always saturate regardless of the policy. Sum writes into the provided Bits
+// that are required to be wide enough to never overflow, so the policy doesn't apply to it.
+type Overflow uint
+
+const (
+	// OverflowWrap is the default policy, the regular unsigned semantic applies and
+	// the integer is wrapped around or truncated, the warning is returned along with it.
+	OverflowWrap Overflow = iota
+	// OverflowSaturate policy clamps the integer to its min value 0 or to
+	// its max value 2^blen-1 instead of wrapping, the warning is returned along with it.
+	OverflowSaturate
+	// OverflowReject policy leaves the integer unchanged and
+	// treats the warning as a regular operation error.
+	OverflowReject
+)
+
+// NewVarIntOverflow allocates and returns VarInt instance that is capable to fit the provided
+// number of integers each of the provided bit len in width with the provided overflow policy.
+// In case the provided overflow policy is unknown, invalid number and ErrorOverflowPolicyIsInvalid is returned.
+// Otherwise, it has exactly the same semantic as NewVarInt.
+// See VarInt and Overflow types for more details.
+func NewVarIntOverflow(blen, len int, overflow Overflow) (VarInt, error) {
+	if overflow > OverflowReject {
+		return nil, ErrorOverflowPolicyIsInvalid
+	}
+	vint, err := NewVarInt(blen, len)
+	if vint == nil {
+		return nil, err
+	}
+	// Store the policy inside the reserved
+	// high bits of bit length header.
+	vint[1] |= uint(overflow) << (wsize - obits)
+	return vint, err
+}
+
+// OverflowPolicy returns overflow policy of the VarInt instance.
+// OverflowPolicy is standalone function by choice to make
+// VarInt more consistent and ergonomic.
+// It's safe to use on nil VarInt, OverflowWrap is returned.
+func OverflowPolicy(vint VarInt) Overflow {
+	if vint == nil {
+		return OverflowWrap
+	}
+	return Overflow(vint[1] >> (wsize - obits))
+}
+
+// overflow internal helper that applies the overflow policy of VarInt to the integer
+// at the provided index after the provided arithmetic warning has already occurred.
+func (vint VarInt) overflow(i int, err error) error {
+	if OverflowPolicy(vint) == OverflowSaturate {
+		return vint.sat(i, err)
+	}
+	return err
+}
+
+// addo internal helper that checks whether the addition of the operand to the integer
+// inside VarInt at the provided index overflows the bit len without changing the integer.
+func (vint VarInt) addo(i int, x operand) bool {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	var carry, w uint
+	for k := 0; k < words; k++ {
+		w, carry = math_bits.Add(wget(vint, i, k), x.word(k), carry)
+	}
+	// For partial high word the carry flag is never
+	// set, instead the overflow is inside excess bits.
+	if n := blen % wsize; n != 0 {
+		carry = w >> n
+	}
+	return carry > 0
+}
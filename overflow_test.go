@@ -0,0 +1,163 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntOverflow(t *testing.T) {
+	const len = 10
+	test("New", t, func(th h) {
+		table := map[string]struct {
+			blen     int
+			len      int
+			overflow Overflow
+			err      error
+		}{
+			"invalid overflow policy should resolve in expected error": {
+				blen:     len,
+				len:      len,
+				overflow: OverflowReject + 1,
+				err:      ErrorOverflowPolicyIsInvalid,
+			},
+			"zero bits len should resolve in expected error": {
+				blen:     0,
+				len:      len,
+				overflow: OverflowSaturate,
+				err:      ErrorBitLengthIsNotPositive,
+			},
+			"small len should resolve in valid vint but warning": {
+				blen:     len,
+				len:      3,
+				overflow: OverflowReject,
+				err:      ErrorLengthIsNotEfficient,
+			},
+			"positive bits len and len resolve in valid vint": {
+				blen:     wsize + 1,
+				len:      len,
+				overflow: OverflowSaturate,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				vint, err := NewVarIntOverflow(tcase.blen, tcase.len, tcase.overflow)
+				h.Equal(err, tcase.err)
+				if vint == nil {
+					return
+				}
+				h.Equal(OverflowPolicy(vint), tcase.overflow)
+				h.Equal(BitLen(vint), tcase.blen)
+				h.Equal(Len(vint), tcase.len)
+				// Growth has to keep the policy.
+				vint, _ = vint.Append(NewBits(tcase.blen, nil))
+				h.Equal(OverflowPolicy(vint), tcase.overflow)
+				h.Equal(BitLen(vint), tcase.blen)
+			})
+		}
+		th.Equal(OverflowPolicy(nil), OverflowWrap)
+		th.Equal(OverflowPolicy(th.NewVarInt(len, len)), OverflowWrap)
+	})
+	test("Rand", t, func(h h) {
+		// Apply random arithmetic operation or its bulk counterpart
+		// to VarInt with random overflow policy and compare the result
+		// and the warning with the same big.Int operation with the policy.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 4, 7, 63, 64, 65, 100, 128, 129, 200}[rnd.Int()%10], rnd.Int()%10+1
+			overflow := Overflow(rnd.Int() % 3)
+			i := rnd.Int() % l
+			vint, _ := NewVarIntOverflow(blen, l, overflow)
+			vintx := h.NewVarInt(blen, l)
+			h.VarInt = vint
+			for x := 0; x < l; x++ {
+				_ = vint.Set(x, NewBitsRand(blen, rnd))
+				bits := NewBitsRand(blen, rnd)
+				if rnd.Int()%2 == 0 {
+					bits = NewBits(blen, []uint{uint(rnd.Int() % (blen + 2))})
+				}
+				_ = vintx.Set(x, bits)
+			}
+			j := rnd.Int() % l
+			vintc := append(VarInt(nil), vint...)
+			ai, bits := h.VarIntGet(i), NewBits(blen, nil)
+			_ = vintx.Get(i, bits)
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			max := new(big.Int).Sub(lim, big.NewInt(1))
+			a, b, r := ai.BigInt(), bits.BigInt(), new(big.Int)
+			op := rnd.Int() % 3
+			var expected error
+			switch op {
+			case 0:
+				if r.Add(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorAdditionOverflow
+				}
+			case 1:
+				if r.Sub(a, b); r.Sign() < 0 {
+					expected = ErrorSubtractionUnderflow
+				}
+			default:
+				if r.Mul(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorMultiplicationOverflow
+				}
+			}
+			if expected != nil {
+				switch overflow {
+				case OverflowSaturate:
+					r.Set(max)
+					if expected == ErrorSubtractionUnderflow {
+						r.SetInt64(0)
+					}
+				case OverflowReject:
+					r.Set(a)
+				}
+			}
+			// Pick random counterpart of the operation, the range
+			// and element-wise counterparts report the same
+			// warning wrapped into RangeError.
+			var err error
+			bulk := rnd.Int() % 5
+			switch bulk {
+			case 0:
+				err = []func(int, Bits) error{vint.Add, vint.Sub, vint.Mul}[op](i, bits)
+			case 1:
+				err = []func(int, Bits) error{vint.AddAny, vint.SubAny, vint.MulAny}[op](i, bits)
+			case 2:
+				err = []func(int, int, Bits) error{vint.AddRange, vint.SubRange, vint.MulRange}[op](i, i+1, bits)
+			case 3:
+				// Use the operand integer inside
+				// the same VarInt at index j.
+				_ = vint.Set(j, bits)
+				err = []func(int, int) error{vint.AddAt, vint.SubAt, vint.MulAt}[op](i, j)
+				if i == j {
+					// Index to itself operand is
+					// the integer itself, skip it.
+					continue
+				}
+			default:
+				// Apply the operation element-wise with identity
+				// operands for all the integers except index i.
+				for x := 0; x < l; x++ {
+					if x != i {
+						_ = vintx.Set(x, NewBits(blen, []uint{uint(op / 2)}))
+					}
+				}
+				err = []func(VarInt, VarInt) error{vint.AddVarInt, vint.SubVarInt, vint.MulVarInt}[op](vintx, vint)
+			}
+			if (bulk == 2 || bulk == 4) && expected != nil {
+				h.Equal(err, &RangeError{Err: expected, Indexes: []int{i}})
+			} else {
+				h.Equal(err, expected)
+			}
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.And(r, max))))
+			// Restore the changed integers and check that
+			// all other integers are left unchanged.
+			h.VarIntSet(i, ai)
+			if bulk == 3 {
+				c := NewBits(blen, nil)
+				_ = VarInt(vintc).Get(j, c)
+				h.VarIntSet(j, c)
+			}
+			_, _ = bvar(vint, true), bvar(vintc, true)
+			h.Equal(vint, vintc)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package varint
+
+// Pow raises the integer inside VarInt at the provided index to the power of the provided exp bits
+// with square and multiply method, the provided exp bits can have any bit len.
+// The intermediate power is kept inside the integer itself and the base copy is kept either inside the tmp
+// bits variable for wide integers or inside a fixed size array on stack for narrow integers, so Pow doesn't allocate.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the exponentiation result overflows the bit len, the integer is truncated and
+// extra ErrorMultiplicationOverflow warning is returned, unless other VarInt Overflow policy is used.
+func (vint VarInt) Pow(i int, exp Bits) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Keep the base copy inside the tmp bits variable for wide integers,
+	// as Karatsuba multiplication uses only the collocated scratch words,
+	// or inside a fixed size array on stack for narrow integers.
+	var stack [2*kthreshold + 1]uint
+	base := bvar(vint, false)
+	if kvar(vint) == nil {
+		base = Bits(stack[:words+1])
+	}
+	base[0] = uint(blen)
+	baseb := base.Bytes()
+	for k := 0; k < words; k++ {
+		baseb[k] = wget(vint, i, k)
+		wset(vint, i, k, 0)
+	}
+	wset(vint, i, 0, 1)
+	// Any intermediate power is never greater than the final power,
+	// so the final power overflows only if any multiplication overflows.
+	var overflow, run bool
+	x := operand{bits: base, blen: blen}
+	expb := exp.Bytes()
+	for b := exp.BitLen() - 1; b >= 0; b-- {
+		// Skip squaring until the first exp bit set,
+		// as the intermediate power is still 1.
+		if run {
+			overflow = vint.mulv(i, operand{vint: vint, j: i}, vint) != nil || overflow
+		}
+		if expb[b/wsize]>>(b%wsize)&1 == 1 {
+			overflow = vint.mulv(i, x, vint) != nil || overflow
+			run = true
+		}
+	}
+	if !overflow {
+		return nil
+	}
+	// In case of reject overflow policy, the integer
+	// is restored back from the base copy.
+	if OverflowPolicy(vint) == OverflowReject {
+		for k := 0; k < words; k++ {
+			wset(vint, i, k, baseb[k])
+		}
+		return ErrorMultiplicationOverflow
+	}
+	return vint.overflow(i, ErrorMultiplicationOverflow)
+}
+
+// ModPow raises the integer inside VarInt at the provided index to the power of the provided exp bits
+// modulo the provided mod bits with square and multiply method, the provided exp bits can have any bit len.
+// All the intermediate products are reduced modulo the mod bits, so the result never overflows the bit len.
+// For odd mod bits it uses Montgomery multiplication, for even mod bits it reduces every full product
+// with the regular long division instead. In both cases the intermediate state is kept either inside
+// the collocated Karatsuba scratch words for wide integers or inside a fixed size array on stack for narrow
+// integers, so ModPow doesn't allocate.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided mod bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+func (vint VarInt) ModPow(i int, exp, mod Bits) error {
+	if err := vint.cbits(i, mod); err != nil {
+		return err
+	}
+	if mod.Empty() {
+		return ErrorDivisionByZero
+	}
+	if mod[1]&1 == 1 {
+		vint.mpowv(i, exp, mod)
+		return nil
+	}
+	vint.dpowv(i, exp, mod)
+	return nil
+}
+
+// mpowv internal helper that raises the integer inside VarInt at the provided index to the power
+// of the provided exp bits modulo the provided odd mod bits with Montgomery multiplication.
+// The modulus, the intermediate power, the base and Montgomery temp buffer are kept inside the scratch words.
+func (vint VarInt) mpowv(i int, exp, mod Bits) {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	var stack [4 * 2 * kthreshold]uint
+	t := stack[:]
+	if kv := kvar(vint); kv != nil {
+		t = kv
+	}
+	nb, p, b, mt := t[:words], Bits(t[words:2*words+1]), t[2*words+1:3*words+1], t[3*words+1:4*words+3]
+	x := operand{bits: mod, blen: blen}
+	for k := 0; k < words; k++ {
+		nb[k] = x.word(k)
+	}
+	n0 := mneg(nb[0])
+	// Convert the base and the intermediate power 1 into Montgomery form,
+	// the modulus could have leading zero bits, so R^2 mod modulus constant
+	// isn't used and the integers are converted by modular doubling instead.
+	p[0] = uint(blen)
+	pb := p.Bytes()
+	for k := range pb {
+		pb[k] = 0
+	}
+	pb[0] = 1
+	px := operand{bits: p, blen: blen}
+	mform(mt, px, nb)
+	copy(pb, mt[:words])
+	mform(mt, operand{vint: vint, j: i, blen: blen}, nb)
+	copy(b, mt[:words])
+	var run bool
+	expb := exp.Bytes()
+	for e := exp.BitLen() - 1; e >= 0; e-- {
+		// Skip squaring until the first exp bit set,
+		// as the intermediate power is still 1.
+		if run {
+			mmul(mt, px, pb, nb, n0)
+			copy(pb, mt[:words])
+		}
+		if expb[e/wsize]>>(e%wsize)&1 == 1 {
+			mmul(mt, px, b, nb, n0)
+			copy(pb, mt[:words])
+			run = true
+		}
+	}
+	// Convert the power back from Montgomery form
+	// by Montgomery multiplication with plain 1.
+	for k := range b {
+		b[k] = 0
+	}
+	b[0] = 1
+	mmul(mt, px, b, nb, n0)
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, mt[k])
+	}
+}
+
+// dpowv internal helper that raises the integer inside VarInt at the provided index to the power
+// of the provided exp bits modulo the provided mod bits with the regular long division reduction.
+// The reduced base is kept inside the tmp bits variable, while the intermediate power and the full
+// product of double words, which is reduced in place, are kept inside the scratch words.
+func (vint VarInt) dpowv(i int, exp, mod Bits) {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	var stack [4 * 2 * kthreshold]uint
+	t, kv := stack[:], kvar(vint)
+	if kv != nil {
+		t = kv
+	}
+	b := bvar(vint, true).Bytes()
+	p, pw, kt := Bits(t[:2*words+1]), t[2*words+1:3*words+1], t[3*words+1:]
+	p[0] = uint(2 * words * wsize)
+	z, zp, m := p.Bytes(), operand{bits: p, blen: 2 * words * wsize}, operand{bits: mod, blen: 2 * words * wsize}
+	// Reduce the base and the intermediate power 1 first,
+	// as the integer and even 1 could be not less than mod.
+	for k := 0; k < 2*words; k++ {
+		z[k] = 0
+		if k < words {
+			z[k] = wget(vint, i, k)
+		}
+	}
+	wdiv(zp, m, zp, z, false)
+	copy(b, z[:words])
+	for k := range z {
+		z[k] = 0
+	}
+	z[0] = 1
+	wdiv(zp, m, zp, z, false)
+	copy(pw, z[:words])
+	var run bool
+	expb := exp.Bytes()
+	for e := exp.BitLen() - 1; e >= 0; e-- {
+		// Skip squaring until the first exp bit set,
+		// as the intermediate power is still 1.
+		if run {
+			dmul(z, pw, pw, kt, kv != nil)
+			wdiv(zp, m, zp, z, false)
+			copy(pw, z[:words])
+		}
+		if expb[e/wsize]>>(e%wsize)&1 == 1 {
+			dmul(z, pw, b, kt, kv != nil)
+			wdiv(zp, m, zp, z, false)
+			copy(pw, z[:words])
+			run = true
+		}
+	}
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, pw[k])
+	}
+}
+
+// dmul internal helper that sets the full product of the provided words x and y
+// into the provided words z of double length, with Karatsuba multiplication
+// on the provided scratch words for wide integers or long multiplication otherwise.
+func dmul(z, x, y, t []uint, wide bool) {
+	if wide {
+		kmul(z, x, y, t)
+		return
+	}
+	lmul(z, x, y)
+}
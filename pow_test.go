@@ -0,0 +1,131 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntPowOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i    int
+			mod  Bits
+			err  error
+		}{
+			"power operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				mod:  NewBits(len, []uint{1}),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"power operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    -1,
+				mod:  NewBits(len, []uint{1}),
+				err:  ErrorIndexIsNegative,
+			},
+			"power operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				mod:  NewBits(len, []uint{1}),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"modular power operation should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				mod:  NewBits(2*len, []uint{1}),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+			"modular power operation should return zero division error on division by zero": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				mod:  NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				exp := NewBitsUint(3)
+				// Pow doesn't have any modulus to validate.
+				switch tcase.err {
+				case ErrorUnequalBitLengthCardinality, ErrorDivisionByZero:
+					h.NoError(tcase.vint.Pow(tcase.i, exp))
+				default:
+					h.Equal(tcase.vint.Pow(tcase.i, exp), tcase.err)
+				}
+				h.Equal(tcase.vint.ModPow(tcase.i, exp, tcase.mod), tcase.err)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply Pow and ModPow with random exponents of any bit len
+		// and compare the result and the warning with the same
+		// big.Int operation for all overflow policies.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 63, 64, 65, 128, 129, 200, 521, 3000, 6000}[rnd.Int()%11], rnd.Int()%4+1
+			i := rnd.Int() % l
+			overflow := Overflow(rnd.Int() % 3)
+			vint, _ := NewVarIntOverflow(blen, l, overflow)
+			h.VarInt = vint
+			for x := 0; x < l; x++ {
+				h.VarIntSet(x, NewBitsRand(blen, rnd))
+			}
+			// Make sure small bases and exponents are checked,
+			// so both overflowed and not overflowed powers are checked.
+			if rnd.Int()%2 == 0 {
+				h.VarIntSet(i, NewBitsBits(blen, NewBitsUint(uint(rnd.Int()%5))))
+			}
+			exp := NewBitsUint(uint(rnd.Int() % 20))
+			if rnd.Int()%4 == 0 {
+				exp = NewBitsRand(rnd.Int()%300+1, rnd)
+			}
+			a := h.VarIntGet(i).BigInt()
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			if rnd.Int()%2 == 0 {
+				mod := NewBitsRand(blen, rnd)
+				if mod.Empty() {
+					mod = NewBitsBits(blen, NewBitsUint(1))
+				}
+				r := new(big.Int).Exp(a, exp.BigInt(), mod.BigInt())
+				h.NoError(vint.ModPow(i, exp, mod))
+				h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r)))
+				continue
+			}
+			// Big exponents are only applicable to small bases.
+			if exp.BitLen() > 20 && a.Cmp(big.NewInt(1)) > 0 {
+				h.VarIntSet(i, NewBits(blen, []uint{1}))
+				a.SetInt64(1)
+			}
+			r := new(big.Int).Exp(a, exp.BigInt(), nil)
+			var expected error
+			if r.Cmp(lim) >= 0 {
+				expected = ErrorMultiplicationOverflow
+				switch overflow {
+				case OverflowSaturate:
+					r.Sub(lim, big.NewInt(1))
+				case OverflowReject:
+					r.Set(a)
+				}
+			}
+			h.Equal(vint.Pow(i, exp), expected)
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r.Mod(r, lim))))
+		}
+	})
+	test("Allocs", t, func(h h) {
+		// Pow and ModPow with both odd and even modulus don't allocate any new memory.
+		for _, blen := range []int{100, 3000, 6000} {
+			vint, exp, mod := h.NewVarInt(blen, 10), NewBitsRand(100, rnd), NewBitsRand(blen, rnd)
+			mod[1] |= 1
+			emod := NewBitsBits(blen, mod)
+			emod[1] &^= 1
+			allocs := testing.AllocsPerRun(10, func() {
+				_ = vint.Pow(1, exp)
+				_ = vint.ModPow(2, exp, mod)
+				_ = vint.ModPow(3, exp, emod)
+			})
+			h.Equal(allocs, 0.0)
+		}
+	})
+}
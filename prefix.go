@@ -0,0 +1,209 @@
+package varint
+
+import math_bits "math/bits"
+
+// PrefixSum sets the integers inside the provided destination VarInt to the prefix sums of the integers inside
+// the provided source VarInt. In inclusive mode dst[i] = src[0] + ... + src[i], otherwise in exclusive mode
+// dst[0] = 0 and dst[i] = src[0] + ... + src[i-1]. The destination could have greater bit len than the source
+// to fit all the prefix sums, or it could be the source VarInt itself to apply the operation in place.
+// The running sum is kept inside the tmp bits variable of the destination, so PrefixSum doesn't allocate any memory.
+// In case the operation is used on invalid nil source or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination has lower bit len than the source, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case any prefix sum overflows the destination bit len, the regular unsigned semantic applies and
+// extra *RangeError warning wrapping ErrorAdditionOverflow with the first overflowed index is returned,
+// unless other destination VarInt Overflow policy is used. For OverflowSaturate policy the overflowed
+// prefix sum and all the following prefix sums are clamped to the max value. For OverflowReject policy
+// the destination is left unchanged entirely and the same *RangeError is returned as an error.
+func PrefixSum(src, dst VarInt, inclusive bool) error {
+	if err := cprefix(src, dst); err != nil {
+		return err
+	}
+	if BitLen(dst) < BitLen(src) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	// In case of reject overflow policy, check all the prefix sums first
+	// without writing them, so the destination is left unchanged.
+	if OverflowPolicy(dst) == OverflowReject {
+		if first := prefixv(src, dst, inclusive, false); first >= 0 {
+			return &RangeError{Err: ErrorAdditionOverflow, Indexes: []int{first}}
+		}
+	}
+	if first := prefixv(src, dst, inclusive, true); first >= 0 {
+		return &RangeError{Err: ErrorAdditionOverflow, Indexes: []int{first}}
+	}
+	return nil
+}
+
+// Difference sets the integers inside the provided destination VarInt to the differences of the adjacent integers
+// inside the provided source VarInt, dst[0] = src[0] and dst[i] = src[i] - src[i-1], which is the inverse of
+// the inclusive PrefixSum. The destination could have lower bit len than the source, if all the differences
+// fit into it, or it could be the source VarInt itself to apply the operation in place. The previous source
+// integer is kept inside the tmp bits variable of the source, so Difference doesn't allocate any memory.
+// In case the operation is used on invalid nil source or destination, ErrorVarIntIsInvalid is returned.
+// In case the destination has greater bit len than the source, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided VarInts have different len, ErrorUnequalLengthCardinality is returned.
+// In case any difference underflows, the regular unsigned semantic applies and extra *RangeError
+// warning wrapping ErrorSubtractionUnderflow with the first underflowed index is returned.
+// In case any difference doesn't fit into the destination bit len, it is truncated and extra *RangeError
+// warning wrapping ErrorBitLengthIsTruncated with the first truncated index is returned.
+// Both warnings respect the destination VarInt Overflow policy. For OverflowSaturate policy the underflowed
+// differences are clamped to 0 and the truncated differences are clamped to the max value. For OverflowReject
+// policy the destination is left unchanged entirely and the same *RangeError is returned as an error.
+func Difference(src, dst VarInt) error {
+	if err := cprefix(src, dst); err != nil {
+		return err
+	}
+	if BitLen(dst) > BitLen(src) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	// In case of reject overflow policy, check all the differences first
+	// without writing them, so the destination is left unchanged.
+	if OverflowPolicy(dst) == OverflowReject {
+		if rerr := diffv(src, dst, false); rerr != nil {
+			return rerr
+		}
+	}
+	// Explicitly return nil interface
+	// instead of nil *RangeError.
+	if rerr := diffv(src, dst, true); rerr != nil {
+		return rerr
+	}
+	return nil
+}
+
+// cprefix internal helper that validates
+// the provided source and destination VarInt.
+func cprefix(src, dst VarInt) error {
+	// Check explicitly for invalid numbers.
+	if src == nil || dst == nil {
+		return ErrorVarIntIsInvalid
+	}
+	if Len(dst) != Len(src) {
+		return ErrorUnequalLengthCardinality
+	}
+	return nil
+}
+
+// prefixv internal core of PrefixSum operation, it returns the first index of the destination
+// integer that is overflowed or -1 if there is none. In case write flag is not set,
+// the destination integers are not changed, only the overflow is checked.
+func prefixv(src, dst VarInt, inclusive, write bool) int {
+	dblen := BitLen(dst)
+	swords, dwords := (BitLen(src)+wsize-1)/wsize, (dblen+wsize-1)/wsize
+	saturate := write && OverflowPolicy(dst) == OverflowSaturate
+	sum := bvar(dst, true).Bytes()
+	first := -1
+	for i, l := 0, Len(src); i < l; i++ {
+		var carry uint
+		for k := 0; k < dwords; k++ {
+			// Note that in place the source word is always
+			// read before the same destination word is written.
+			var w uint
+			if k < swords {
+				w = wget(src, i, k)
+			}
+			prev := sum[k]
+			// Saturated sum stays at the max value.
+			if first < 0 || !saturate {
+				sum[k], carry = math_bits.Add(prev, w, carry)
+			}
+			if write && !inclusive {
+				wset(dst, i, k, prev)
+			}
+		}
+		// For partial high word check excess bits for the
+		// overflow and truncate them to keep the unsigned semantic.
+		if n := dblen % wsize; n != 0 {
+			carry |= sum[dwords-1] >> n
+			sum[dwords-1] &= 1<<n - 1
+		}
+		if carry != 0 && first < 0 {
+			first = i
+			// All the following prefix sums overflow as well,
+			// so the sum is clamped to the max value once.
+			if saturate {
+				for k := 0; k < dwords; k++ {
+					sum[k] = ^uint(0)
+				}
+				if n := dblen % wsize; n != 0 {
+					sum[dwords-1] = 1<<n - 1
+				}
+			}
+		}
+		if write && inclusive {
+			for k := 0; k < dwords; k++ {
+				wset(dst, i, k, sum[k])
+			}
+		}
+	}
+	// In exclusive mode the overflow is observed
+	// only on the integer next to the overflowed sum.
+	if first >= 0 && !inclusive {
+		first++
+	}
+	if first >= Len(src) {
+		return -1
+	}
+	return first
+}
+
+// diffv internal core of Difference operation, it returns *RangeError with the first index
+// of the destination integer that is underflowed or truncated or nil if there is none.
+// In case write flag is not set, the destination integers are not changed, only the errors are checked.
+func diffv(src, dst VarInt, write bool) *RangeError {
+	dblen := BitLen(dst)
+	swords, dwords := (BitLen(src)+wsize-1)/wsize, (dblen+wsize-1)/wsize
+	saturate := write && OverflowPolicy(dst) == OverflowSaturate
+	prev := bvar(src, true).Bytes()
+	var rerr *RangeError
+	for i, l := 0, Len(src); i < l; i++ {
+		var borrow, excess uint
+		for k := 0; k < swords; k++ {
+			// Note that in place the source word is always
+			// read before the same destination word is written.
+			w := wget(src, i, k)
+			var d uint
+			d, borrow = math_bits.Sub(w, prev[k], borrow)
+			prev[k] = w
+			switch {
+			case k < dwords-1:
+				if write {
+					wset(dst, i, k, d)
+				}
+			case k == dwords-1:
+				if write {
+					wset(dst, i, k, d)
+				}
+				if n := dblen % wsize; n != 0 {
+					excess |= d >> n
+				}
+			default:
+				excess |= d
+			}
+		}
+		if borrow == 0 && excess == 0 {
+			continue
+		}
+		if rerr == nil {
+			switch {
+			case borrow != 0:
+				rerr = &RangeError{Err: ErrorSubtractionUnderflow, Indexes: []int{i}}
+			default:
+				rerr = &RangeError{Err: ErrorBitLengthIsTruncated, Indexes: []int{i}}
+			}
+		}
+		// Clamp the difference to 0 on underflow or to the max value
+		// on truncation, the source integer is already read entirely.
+		if saturate {
+			var w uint
+			if borrow == 0 {
+				w = ^uint(0)
+			}
+			for k := 0; k < dwords; k++ {
+				wset(dst, i, k, w)
+			}
+		}
+	}
+	return rerr
+}
@@ -0,0 +1,242 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestPrefixSum(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			src VarInt
+			dst VarInt
+			err error
+		}{
+			"prefix operations should return invalid varint error": {
+				src: nil,
+				dst: th.NewVarInt(len, len),
+				err: ErrorVarIntIsInvalid,
+			},
+			"prefix operations should return invalid varint error on nil dst": {
+				src: th.NewVarInt(len, len),
+				dst: nil,
+				err: ErrorVarIntIsInvalid,
+			},
+			"prefix operations should return len cardinarity error": {
+				src: th.NewVarInt(len, len),
+				dst: th.NewVarInt(len, len+1),
+				err: ErrorUnequalLengthCardinality,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(PrefixSum(tcase.src, tcase.dst, true), tcase.err)
+				h.Equal(PrefixSum(tcase.src, tcase.dst, false), tcase.err)
+				h.Equal(Difference(tcase.src, tcase.dst), tcase.err)
+			})
+		}
+		// Prefix sums can only widen and differences can only narrow.
+		narrow, wide := th.NewVarInt(len, len), th.NewVarInt(2*len, len)
+		th.Equal(PrefixSum(wide, narrow, true), ErrorUnequalBitLengthCardinality)
+		th.Equal(Difference(narrow, wide), ErrorUnequalBitLengthCardinality)
+	})
+	test("Rand", t, func(h h) {
+		// Apply prefix sums from random narrow varint into random wide varint
+		// or in place and compare the result and the first overflowed index with
+		// the same big.Int prefix sums, then apply the difference to restore it.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 13, 63, 64, 65, 100, 129}[rnd.Int()%8], rnd.Int()%300+1
+			dblen := blen + []int{0, 1, 5, 9, 64, 100}[rnd.Int()%6]
+			inclusive := rnd.Int()%2 == 0
+			src := h.NewVarInt(blen, l)
+			slice := make([]*big.Int, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			dst := src
+			if rnd.Int()%4 != 0 {
+				dst = h.NewVarInt(dblen, l)
+			}
+			dblen = BitLen(dst)
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(dblen))
+			sums, sum := make([]*big.Int, 0, l), new(big.Int)
+			first := -1
+			for i := 0; i < l; i++ {
+				if !inclusive {
+					sums = append(sums, new(big.Int).Mod(sum, lim))
+				}
+				sum.Add(sum, slice[i])
+				if inclusive {
+					sums = append(sums, new(big.Int).Mod(sum, lim))
+				}
+				if first < 0 && sum.Cmp(lim) >= 0 {
+					first = i
+					if !inclusive {
+						first++
+					}
+				}
+			}
+			var expected error
+			if first >= 0 && first < l {
+				expected = &RangeError{Err: ErrorAdditionOverflow, Indexes: []int{first}}
+			}
+			h.Equal(PrefixSum(src, dst, inclusive), expected)
+			h.VarInt = dst
+			for i := 0; i < l; i++ {
+				h.VarIntEqual(i, NewBitsBits(dblen, NewBitsBigInt(sums[i])))
+			}
+			// Inclusive prefix sums without overflow are
+			// restored back exactly by the difference.
+			if !inclusive || expected != nil {
+				continue
+			}
+			back := dst
+			if rnd.Int()%2 == 0 {
+				back = h.NewVarInt(blen, l)
+			}
+			h.NoError(Difference(dst, back))
+			h.VarInt = back
+			for i := 0; i < l; i++ {
+				h.VarIntEqual(i, NewBitsBits(BitLen(back), NewBitsBigInt(slice[i])))
+			}
+		}
+	})
+	test("Difference", t, func(h h) {
+		// Apply the difference to random wide varint into random narrow
+		// varint and compare the result and the first underflowed or
+		// truncated index with the same big.Int differences.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 13, 63, 64, 65, 100, 129}[rnd.Int()%8], rnd.Int()%300+1
+			dblen := blen - rnd.Int()%blen
+			src, dst := h.NewVarInt(blen, l), h.NewVarInt(dblen, l)
+			h.VarInt = src
+			slice := make([]*big.Int, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(dblen))
+			var expected error
+			diffs, prev := make([]*big.Int, 0, l), new(big.Int)
+			for i := 0; i < l; i++ {
+				d := new(big.Int).Sub(slice[i], prev)
+				prev = slice[i]
+				if expected == nil {
+					switch {
+					case d.Sign() < 0:
+						expected = &RangeError{Err: ErrorSubtractionUnderflow, Indexes: []int{i}}
+					case d.Cmp(lim) >= 0:
+						expected = &RangeError{Err: ErrorBitLengthIsTruncated, Indexes: []int{i}}
+					}
+				}
+				diffs = append(diffs, d.Mod(d, lim))
+			}
+			h.Equal(Difference(src, dst), expected)
+			h.VarInt = dst
+			for i := 0; i < l; i++ {
+				h.VarIntEqual(i, NewBitsBits(dblen, NewBitsBigInt(diffs[i])))
+			}
+		}
+	})
+	test("Overflow", t, func(h h) {
+		// Apply the prefix sums and the differences to random destination
+		// varint with random overflow policy and compare the result and the
+		// first failed index with the same big.Int operations, rejected
+		// operations have to leave the destination unchanged entirely.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 13, 63, 64, 65, 100, 129}[rnd.Int()%8], rnd.Int()%300+1
+			overflow := Overflow(rnd.Int() % 3)
+			diff := rnd.Int()%2 == 0
+			dblen := blen + rnd.Int()%3
+			if diff {
+				dblen = blen - rnd.Int()%blen
+			}
+			src := h.NewVarInt(blen, l)
+			slice := make([]*big.Int, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			dst, _ := NewVarIntOverflow(dblen, l, overflow)
+			h.VarInt = dst
+			prev := make([]Bits, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(dblen, rnd))
+				prev = append(prev, h.VarIntGet(i))
+			}
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(dblen))
+			max := new(big.Int).Sub(lim, big.NewInt(1))
+			inclusive := rnd.Int()%2 == 0
+			results := make([]*big.Int, 0, l)
+			var expected *RangeError
+			fail := func(i int, err error) {
+				if expected == nil {
+					expected = &RangeError{Err: err, Indexes: []int{i}}
+				}
+			}
+			sum, last := new(big.Int), new(big.Int)
+			for i := 0; i < l; i++ {
+				var r *big.Int
+				switch {
+				case diff:
+					r = new(big.Int).Sub(slice[i], last)
+					last = slice[i]
+				case inclusive:
+					r = new(big.Int).Set(sum.Add(sum, slice[i]))
+				default:
+					r = new(big.Int).Set(sum)
+					sum.Add(sum, slice[i])
+				}
+				switch {
+				case r.Sign() < 0:
+					fail(i, ErrorSubtractionUnderflow)
+					if overflow == OverflowSaturate {
+						r.SetInt64(0)
+					}
+				case r.Cmp(lim) >= 0 && diff:
+					fail(i, ErrorBitLengthIsTruncated)
+					if overflow == OverflowSaturate {
+						r.Set(max)
+					}
+				case r.Cmp(lim) >= 0:
+					fail(i, ErrorAdditionOverflow)
+					if overflow == OverflowSaturate {
+						r.Set(max)
+					}
+				}
+				results = append(results, r.Mod(r, lim))
+			}
+			var err error
+			if diff {
+				err = Difference(src, dst)
+			} else {
+				err = PrefixSum(src, dst, inclusive)
+			}
+			if expected == nil {
+				h.NoError(err)
+			} else {
+				h.Equal(err, expected)
+			}
+			for i := 0; i < l; i++ {
+				if expected != nil && overflow == OverflowReject {
+					h.VarIntEqual(i, prev[i])
+					continue
+				}
+				h.VarIntEqual(i, NewBitsBits(dblen, NewBitsBigInt(results[i])))
+			}
+		}
+		// Saturated prefix sums are clamped to the max value.
+		src := h.NewVarInt(8, 4)
+		for i := 0; i < 4; i++ {
+			h.VarIntSet(i, NewBits(8, []uint{200}))
+		}
+		dst, _ := NewVarIntOverflow(8, 4, OverflowSaturate)
+		h.Equal(PrefixSum(src, dst, true), &RangeError{Err: ErrorAdditionOverflow, Indexes: []int{1}})
+		h.VarInt = dst
+		for i, w := range []uint{200, 255, 255, 255} {
+			h.VarIntEqual(i, NewBits(8, []uint{w}))
+		}
+	})
+}
@@ -0,0 +1,399 @@
+package varint
+
+import math_bits "math/bits"
+
+// AddRange adds the provided bits to all the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the addition result overflows the bit len for any integer, the regular unsigned semantic applies and
+// extra *RangeError warning wrapping ErrorAdditionOverflow with all the overflowed indexes is returned.
+func (vint VarInt) AddRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	return vint.arithr(from, to, bits, false)
+}
+
+// SubRange subtracts the provided bits from all the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the subtraction result underflows any integer, the regular unsigned semantic applies and
+// extra *RangeError warning wrapping ErrorSubtractionUnderflow with all the underflowed indexes is returned.
+func (vint VarInt) SubRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	return vint.arithr(from, to, bits, true)
+}
+
+// MulRange multiplies the provided bits with all the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the multiplication result overflows the bit len for any integer, the integer is truncated and
+// extra *RangeError warning wrapping ErrorMultiplicationOverflow with all the overflowed indexes is returned.
+func (vint VarInt) MulRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	return vint.each(from, to, bits, VarInt.mul)
+}
+
+// DivRange divides all the integers inside VarInt in range [from, to) by the provided bits.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned and no integer is changed.
+func (vint VarInt) DivRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	if bits.Empty() {
+		return ErrorDivisionByZero
+	}
+	return vint.each(from, to, bits, VarInt.div)
+}
+
+// ModRange applies modulo operation to all the integers inside VarInt in range [from, to) and the provided bits.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned and no integer is changed.
+func (vint VarInt) ModRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	if bits.Empty() {
+		return ErrorDivisionByZero
+	}
+	return vint.each(from, to, bits, VarInt.mod)
+}
+
+// NotRange applies bitwise negation ^ operation to all the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+func (vint VarInt) NotRange(from, to int) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	vint.bitwr(from, to, nil, func(a, _ uint) uint { return ^a })
+	return nil
+}
+
+// AndRange applies bitwise and & operation to all the integers inside VarInt in range [from, to) and the provided bits.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) AndRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	vint.bitwr(from, to, bits, func(a, b uint) uint { return a & b })
+	return nil
+}
+
+// OrRange applies bitwise or | operation to all the integers inside VarInt in range [from, to) and the provided bits.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) OrRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	vint.bitwr(from, to, bits, func(a, b uint) uint { return a | b })
+	return nil
+}
+
+// XorRange applies bitwise xor ^ operation to all the integers inside VarInt in range [from, to) and the provided bits.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) XorRange(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	vint.bitwr(from, to, bits, func(a, b uint) uint { return a ^ b })
+	return nil
+}
+
+// RshRange applies right shift >> operation to all the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative shift is provided, ErrorShiftIsNegative is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+func (vint VarInt) RshRange(from, to, n int) error {
+	// Check that valid shift is provided,
+	// right after the invalid number check.
+	if vint != nil && n < 0 {
+		return ErrorShiftIsNegative
+	}
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	vint.shiftr(from, to, n, true)
+	return nil
+}
+
+// LshRange applies left shift << operation to all the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative shift is provided, ErrorShiftIsNegative is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+func (vint VarInt) LshRange(from, to, n int) error {
+	// Check that valid shift is provided,
+	// right after the invalid number check.
+	if vint != nil && n < 0 {
+		return ErrorShiftIsNegative
+	}
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	vint.shiftr(from, to, n, false)
+	return nil
+}
+
+// crange internal helper that validates the provided
+// range of integers [from, to) against VarInt.
+func (vint VarInt) crange(from, to int) error {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return ErrorVarIntIsInvalid
+	}
+	// Check that non negative index was provided.
+	if from < 0 {
+		return ErrorIndexIsNegative
+	}
+	// Check that requested range is inside varint range.
+	if length := Len(vint); to > length {
+		return ErrorIndexIsOutOfRange
+	}
+	// Check that requested range is not empty.
+	if to <= from {
+		return ErrorLengthIsNotPositive
+	}
+	return nil
+}
+
+// each internal helper that sequentially applies the provided operation core
+// with the provided bits to all the integers inside VarInt in range [from, to).
+// It aggregates all the operation warnings into a single *RangeError report,
+// note that the operation is expected to return the same warning for all integers.
+func (vint VarInt) each(from, to int, bits Bits, op func(VarInt, int, Bits) error) error {
+	var rerr *RangeError
+	for i := from; i < to; i++ {
+		if err := op(vint, i, bits); err != nil {
+			if rerr == nil {
+				rerr = &RangeError{Err: err}
+			}
+			rerr.Indexes = append(rerr.Indexes, i)
+		}
+	}
+	// Explicitly return nil interface
+	// instead of nil *RangeError.
+	if rerr == nil {
+		return nil
+	}
+	return rerr
+}
+
+// arithr internal core of AddRange and SubRange operations that adds or subtracts the provided bits
+// to or from all the integers inside VarInt in range [from, to). It walks the integers sequentially
+// by moving the ending bit of the current integer by bit len, instead of recalculating it for each word.
+// It honors VarInt Overflow policy and aggregates all the warnings into a single *RangeError report.
+func (vint VarInt) arithr(from, to int, bits Bits, sub bool) error {
+	blen := BitLen(vint)
+	bitsb := bits.Bytes()
+	policy := OverflowPolicy(vint)
+	err := ErrorAdditionOverflow
+	if sub {
+		err = ErrorSubtractionUnderflow
+	}
+	var rerr *RangeError
+	for i, bto := from, blen*(from+1)-1+wsize*2; i < to; i, bto = i+1, bto+blen {
+		// In case of reject overflow policy, check for the overflow
+		// beforehand, so the integer is left unchanged.
+		reject := policy == OverflowReject && vint.arithw(bto, blen, bitsb, sub, false)
+		if !reject && !vint.arithw(bto, blen, bitsb, sub, true) {
+			continue
+		}
+		if policy == OverflowSaturate {
+			_ = vint.sat(i, err)
+		}
+		if rerr == nil {
+			rerr = &RangeError{Err: err}
+		}
+		rerr.Indexes = append(rerr.Indexes, i)
+	}
+	// Explicitly return nil interface
+	// instead of nil *RangeError.
+	if rerr == nil {
+		return nil
+	}
+	return rerr
+}
+
+// arithw internal helper that adds or subtracts the provided bits words to or from the integer
+// that ends at the provided bit inside VarInt and returns true if the result overflows or underflows.
+// In case write flag is not set, the integer is not changed, only the overflow is checked.
+func (vint VarInt) arithw(bto, blen int, bitsb []uint, sub, write bool) bool {
+	var carry, w uint
+	for k, n := 0, blen; n > 0; k, n, bto = k+1, n-wsize, bto-wsize {
+		x := bitsb[k]
+		if n < wsize {
+			x &= 1<<n - 1
+		}
+		if sub {
+			w, carry = math_bits.Sub(wgetb(vint, bto, n), x, carry)
+		} else {
+			w, carry = math_bits.Add(wgetb(vint, bto, n), x, carry)
+		}
+		if write {
+			wsetb(vint, bto, n, w)
+		}
+	}
+	// For partial high word the carry flag is never
+	// set, instead the overflow is inside excess bits.
+	if n := blen % wsize; n != 0 && !sub {
+		carry = w >> n
+	}
+	return carry > 0
+}
+
+// bitwr internal core of bitwise range operations that applies the provided bitwise operation
+// to all the integers inside VarInt in range [from, to) and the provided bits. It walks the packed
+// words of the range sequentially once, combining each packed word with the word of the provided
+// bits repeated over the range and aligned to the packed word, so it doesn't use any integer offsets.
+// In case the provided bits are nil, zero bits are repeated over the range.
+func (vint VarInt) bitwr(from, to int, bits Bits, op func(a, b uint) uint) {
+	blen := BitLen(vint)
+	bitsb := bits.Bytes()
+	// For narrow bit len precalculate two words of the bits
+	// repeated from the most significant bit, so any aligned
+	// word is just a window inside them.
+	var hi, lo uint
+	if blen <= wsize && len(bitsb) > 0 {
+		v := bitsb[0] & (^uint(0) >> (wsize - blen))
+		for p := 0; p < 2*wsize; p += blen {
+			switch s := 2*wsize - p - blen; {
+			case s >= wsize:
+				hi |= v << (s - wsize)
+			case s >= 0:
+				hi, lo = hi|v>>(wsize-s), lo|v<<s
+			default:
+				lo |= v >> -s
+			}
+		}
+	}
+	// Calculate the starting and ending bit of the range
+	// and the bits phase of the first packed word, i.e. the
+	// offset of the word starting bit inside the repeated bits.
+	bfrom, bto := blen*from+wsize*2, blen*to+wsize*2
+	ph := (blen - bfrom%wsize%blen) % blen
+	for k := bfrom / wsize; k*wsize < bto; k, ph = k+1, (ph+wsize)%blen {
+		var w uint
+		switch {
+		case blen <= wsize:
+			w = hi<<ph | lo>>(wsize-ph)
+		case ph+wsize <= blen:
+			w = wshift(bitsb, blen-ph-wsize, 0)
+		default:
+			// The word wraps around the bits, so combine the bits
+			// tail with the bits head, masking any excess bits.
+			r := ph + wsize - blen
+			w = wshift(bitsb, -r, 0) | wshift(bitsb, blen-r, 0)&(1<<r-1)
+		}
+		// Mask the packed word bits outside of the range.
+		m := ^uint(0)
+		if k == bfrom/wsize {
+			m >>= bfrom % wsize
+		}
+		if n := bto - k*wsize; n < wsize {
+			m &^= ^uint(0) >> n
+		}
+		vint[k] = vint[k]&^m | op(vint[k], w)&m
+	}
+}
+
+// shiftr internal core of RshRange and LshRange operations that shifts all the integers inside VarInt
+// in range [from, to) by the provided shift. It walks the integers sequentially by moving the ending bit
+// of the current integer by bit len, and reads every shifted word directly from the packed words at
+// the shifted bit offset, so it doesn't need to split, clear and combine the words for each integer.
+func (vint VarInt) shiftr(from, to, n int, right bool) {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	for bto := blen*(from+1) - 1 + wsize*2; from < to; from, bto = from+1, bto+blen {
+		// For right shift the words are moved towards the least significant word,
+		// so iterate from low to high word to read every source word before it's
+		// overwritten, and for left shift iterate from high to low word instead.
+		for x := 0; x < words; x++ {
+			k := x
+			if !right {
+				k = words - 1 - x
+			}
+			var w uint
+			b := k * wsize
+			switch {
+			case n >= blen:
+			case right && b+n < blen:
+				w = wgetb(vint, bto-b-n, blen-b-n)
+			case !right && b >= n:
+				w = wgetb(vint, bto-b+n, blen-b+n)
+			case !right && b+wsize > n:
+				// The lowest shifted word is partial, so read
+				// the lowest integer bits and shift them in place.
+				c := b + wsize - n
+				if c > blen {
+					c = blen
+				}
+				w = wgetb(vint, bto, c) << (n - b)
+			}
+			wsetb(vint, bto-b, blen-b, w)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package varint
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVarIntRangeOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint     VarInt
+			from, to int
+			n        int
+			bits     Bits
+			err      error
+		}{
+			"range operations should return invalid varint error": {
+				vint: nil,
+				from: 1,
+				to:   2,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"range operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				from: -1,
+				to:   2,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorIndexIsNegative,
+			},
+			"range operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				from: 1,
+				to:   len + 1,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"range operations should return not positive length error": {
+				vint: th.NewVarInt(len, len),
+				from: 2,
+				to:   1,
+				bits: NewBits(len, []uint{1}),
+				err:  ErrorLengthIsNotPositive,
+			},
+			"range operations should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				from: 1,
+				to:   2,
+				bits: NewBits(2*len, []uint{1}),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+			"range operations should return division by zero error": {
+				vint: th.NewVarInt(len, len),
+				from: 1,
+				to:   2,
+				bits: NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
+			"range shift operations should return negative shift error": {
+				vint: th.NewVarInt(len, len),
+				from: -1,
+				to:   2,
+				n:    -1,
+				err:  ErrorShiftIsNegative,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				if tcase.n < 0 {
+					h.Equal(tcase.vint.RshRange(tcase.from, tcase.to, tcase.n), tcase.err)
+					h.Equal(tcase.vint.LshRange(tcase.from, tcase.to, tcase.n), tcase.err)
+					return
+				}
+				h.Equal(tcase.vint.DivRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.ModRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				if tcase.err == ErrorDivisionByZero {
+					return
+				}
+				h.Equal(tcase.vint.AddRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.SubRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.MulRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.AndRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.OrRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.XorRange(tcase.from, tcase.to, tcase.bits), tcase.err)
+				if tcase.err != ErrorUnequalBitLengthCardinality {
+					h.Equal(tcase.vint.NotRange(tcase.from, tcase.to), tcase.err)
+					h.Equal(tcase.vint.RshRange(tcase.from, tcase.to, 1), tcase.err)
+					h.Equal(tcase.vint.LshRange(tcase.from, tcase.to, 1), tcase.err)
+				}
+			})
+		}
+	})
+	test("Report", t, func(h h) {
+		h.NewVarInt(len, len)
+		h.VarIntSet(1, NewBits(len, []uint{1023}))
+		h.VarIntSet(4, NewBits(len, []uint{1000}))
+		h.VarIntSet(7, NewBits(len, []uint{1020}))
+		err := h.VarInt.AddRange(0, len-1, NewBits(len, []uint{10}))
+		var rerr *RangeError
+		h.Equal(errors.As(err, &rerr), true)
+		h.Equal(errors.Is(err, ErrorAdditionOverflow), true)
+		h.Equal(rerr.Indexes, []int{1, 7})
+		h.VarIntEqual(1, NewBits(len, []uint{9}))
+		h.VarIntEqual(4, NewBits(len, []uint{1010}))
+		h.VarIntEqual(7, NewBits(len, []uint{6}))
+		h.VarIntEqual(8, NewBits(len, []uint{10}))
+		h.VarIntEqual(9, NewBits(len, nil))
+		h.Equal(h.VarInt.SubRange(4, 5, NewBits(len, []uint{10})), nil)
+		h.VarIntEqual(4, NewBits(len, []uint{1000}))
+	})
+	test("Rand", t, func(h h) {
+		// Apply random range operation to the varint and
+		// the same single index operations to its copy on
+		// each index inside the range, aggregating the warnings
+		// manually, then check that both varints and reports match
+		// for any overflow policy, including the packed words outside
+		// of the range that have to stay unchanged.
+		for k := 0; k < 500; k++ {
+			blen, l := rnd.Int()%150+1, rnd.Int()%50+2
+			from := rnd.Int() % (l - 1)
+			to := from + rnd.Int()%(l-from) + 1
+			n, b := rnd.Int()%(blen+wsize+1), NewBitsRand(blen, rnd)
+			vint, _ := NewVarIntOverflow(blen, l, Overflow(rnd.Int()%3))
+			for i := 0; i < l; i++ {
+				_ = vint.Set(i, NewBitsRand(blen, rnd))
+			}
+			vintc := append(VarInt(nil), vint...)
+			var rop func() error
+			var op func(i int) error
+			switch rnd.Int() % 11 {
+			case 0:
+				rop = func() error { return vint.AddRange(from, to, b) }
+				op = func(i int) error { return vintc.Add(i, b) }
+			case 1:
+				rop = func() error { return vint.SubRange(from, to, b) }
+				op = func(i int) error { return vintc.Sub(i, b) }
+			case 2:
+				rop = func() error { return vint.MulRange(from, to, b) }
+				op = func(i int) error { return vintc.Mul(i, b) }
+			case 3:
+				b[1] |= 1
+				rop = func() error { return vint.DivRange(from, to, b) }
+				op = func(i int) error { return vintc.Div(i, b) }
+			case 4:
+				b[1] |= 1
+				rop = func() error { return vint.ModRange(from, to, b) }
+				op = func(i int) error { return vintc.Mod(i, b) }
+			case 5:
+				rop = func() error { return vint.NotRange(from, to) }
+				op = func(i int) error { return vintc.Not(i) }
+			case 6:
+				rop = func() error { return vint.AndRange(from, to, b) }
+				op = func(i int) error { return vintc.And(i, b) }
+			case 7:
+				rop = func() error { return vint.OrRange(from, to, b) }
+				op = func(i int) error { return vintc.Or(i, b) }
+			case 8:
+				rop = func() error { return vint.XorRange(from, to, b) }
+				op = func(i int) error { return vintc.Xor(i, b) }
+			case 9:
+				rop = func() error { return vint.RshRange(from, to, n) }
+				op = func(i int) error { return vintc.Rsh(i, n) }
+			default:
+				rop = func() error { return vint.LshRange(from, to, n) }
+				op = func(i int) error { return vintc.Lsh(i, n) }
+			}
+			var rerr *RangeError
+			for i := from; i < to; i++ {
+				if err := op(i); err != nil {
+					if rerr == nil {
+						rerr = &RangeError{Err: err}
+					}
+					rerr.Indexes = append(rerr.Indexes, i)
+				}
+			}
+			err := rop()
+			if rerr == nil {
+				h.Equal(err, nil)
+			} else {
+				h.Equal(err, rerr)
+			}
+			// Temp bits variable might hold different leftovers,
+			// so clear them before comparing the varints.
+			_, _ = bvar(vint, true), bvar(vintc, true)
+			h.Equal(vint, vintc)
+		}
+	})
+}
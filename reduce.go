@@ -0,0 +1,183 @@
+package varint
+
+import math_bits "math/bits"
+
+// Min sets the provided bits to the minimum integer inside VarInt in range [from, to).
+// It finds the minimum integer in a single sequential pass comparing the packed words directly,
+// and it never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) Min(from, to int, bits Bits) error {
+	if err := vint.creduce(from, to, bits); err != nil {
+		return err
+	}
+	return vint.get(vint.argv(from, to, -1), bits)
+}
+
+// Max sets the provided bits to the maximum integer inside VarInt in range [from, to).
+// It finds the maximum integer in a single sequential pass comparing the packed words directly,
+// and it never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) Max(from, to int, bits Bits) error {
+	if err := vint.creduce(from, to, bits); err != nil {
+		return err
+	}
+	return vint.get(vint.argv(from, to, 1), bits)
+}
+
+// ArgMin returns the index of the first minimum integer inside VarInt in range [from, to).
+// It finds the minimum integer in a single sequential pass comparing the packed words directly,
+// so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+func (vint VarInt) ArgMin(from, to int) (int, error) {
+	if err := vint.crange(from, to); err != nil {
+		return 0, err
+	}
+	return vint.argv(from, to, -1), nil
+}
+
+// ArgMax returns the index of the first maximum integer inside VarInt in range [from, to).
+// It finds the maximum integer in a single sequential pass comparing the packed words directly,
+// so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+func (vint VarInt) ArgMax(from, to int) (int, error) {
+	if err := vint.crange(from, to); err != nil {
+		return 0, err
+	}
+	return vint.argv(from, to, 1), nil
+}
+
+// OrAll sets the provided bits to the bitwise or | of all the integers inside VarInt in range [from, to).
+// It folds the integers in a single sequential pass over the packed words directly into the provided bits,
+// and it never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) OrAll(from, to int, bits Bits) error {
+	if err := vint.creduce(from, to, bits); err != nil {
+		return err
+	}
+	vint.foldv(from, to, bits, func(a, b uint) uint { return a | b })
+	return nil
+}
+
+// AndAll sets the provided bits to the bitwise and & of all the integers inside VarInt in range [from, to).
+// It folds the integers in a single sequential pass over the packed words directly into the provided bits,
+// and it never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) AndAll(from, to int, bits Bits) error {
+	if err := vint.creduce(from, to, bits); err != nil {
+		return err
+	}
+	vint.foldv(from, to, bits, func(a, b uint) uint { return a & b })
+	return nil
+}
+
+// XorAll sets the provided bits to the bitwise xor ^ of all the integers inside VarInt in range [from, to).
+// It folds the integers in a single sequential pass over the packed words directly into the provided bits,
+// and it never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) XorAll(from, to int, bits Bits) error {
+	if err := vint.creduce(from, to, bits); err != nil {
+		return err
+	}
+	vint.foldv(from, to, bits, func(a, b uint) uint { return a ^ b })
+	return nil
+}
+
+// Sum sets the provided bits to the sum of all the integers inside VarInt in range [from, to).
+// The provided bits has to be wide enough to fit the sum of any integers, so its bit len has to be
+// at least bit len of VarInt plus ceil(log2(to - from)), then the sum never overflows.
+// It accumulates the integers in a single sequential pass over the packed words directly into the provided bits,
+// and it never allocates new Bits, the provided Bits are expected to be preallocated by the caller.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case the provided bits bit len is not wide enough, ErrorUnequalBitLengthCardinality is returned.
+func (vint VarInt) Sum(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx < BitLen(vint)+math_bits.Len(uint(to-from-1)) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	words := (BitLen(vint) + wsize - 1) / wsize
+	bitsb := bits.Bytes()
+	for k := range bitsb {
+		bitsb[k] = 0
+	}
+	for i := from; i < to; i++ {
+		var carry uint
+		for k := 0; k < words; k++ {
+			bitsb[k], carry = math_bits.Add(bitsb[k], wget(vint, i, k), carry)
+		}
+		// Propagate the carry through the rest sum words,
+		// the sum words are wide enough to never overflow.
+		for k := words; carry != 0; k++ {
+			bitsb[k], carry = math_bits.Add(bitsb[k], 0, carry)
+		}
+	}
+	return nil
+}
+
+// creduce internal helper that validates the provided
+// range of integers [from, to) and bits against VarInt.
+func (vint VarInt) creduce(from, to int, bits Bits) error {
+	if err := vint.crange(from, to); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	return nil
+}
+
+// argv internal helper that returns the index of the first integer inside VarInt in range [from, to)
+// that compares to all the other integers as the provided cmp, -1 for minimum and 1 for maximum.
+func (vint VarInt) argv(from, to, cmp int) int {
+	arg := from
+	for i := from + 1; i < to; i++ {
+		if vint.cmpv(i, operand{vint: vint, j: arg}) == cmp {
+			arg = i
+		}
+	}
+	return arg
+}
+
+// foldv internal helper that folds all the integers inside VarInt in range [from, to)
+// into the provided bits word by word with the provided bitwise operation.
+func (vint VarInt) foldv(from, to int, bits Bits, op func(a, b uint) uint) {
+	bitsb := bits.Bytes()
+	for k := range bitsb {
+		bitsb[k] = wget(vint, from, k)
+	}
+	for i := from + 1; i < to; i++ {
+		for k := range bitsb {
+			bitsb[k] = op(bitsb[k], wget(vint, i, k))
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package varint
+
+import (
+	"math/big"
+	math_bits "math/bits"
+	"testing"
+)
+
+func TestVarIntReduceOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint     VarInt
+			from, to int
+			bits     Bits
+			err      error
+		}{
+			"reduce operations should return invalid varint error": {
+				vint: nil,
+				from: 1,
+				to:   2,
+				bits: NewBits(len, nil),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"reduce operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				from: -1,
+				to:   2,
+				bits: NewBits(len, nil),
+				err:  ErrorIndexIsNegative,
+			},
+			"reduce operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				from: 1,
+				to:   len + 1,
+				bits: NewBits(len, nil),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"reduce operations should return not positive length error": {
+				vint: th.NewVarInt(len, len),
+				from: 2,
+				to:   2,
+				bits: NewBits(len, nil),
+				err:  ErrorLengthIsNotPositive,
+			},
+			"reduce operations should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				from: 1,
+				to:   2,
+				bits: NewBits(len-1, nil),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(tcase.vint.Min(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.Max(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.OrAll(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.AndAll(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.XorAll(tcase.from, tcase.to, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.Sum(tcase.from, tcase.to, tcase.bits), tcase.err)
+				// Index only operations don't have any bits to validate.
+				if tcase.err != ErrorUnequalBitLengthCardinality {
+					_, err := tcase.vint.ArgMin(tcase.from, tcase.to)
+					h.Equal(err, tcase.err)
+					_, err = tcase.vint.ArgMax(tcase.from, tcase.to)
+					h.Equal(err, tcase.err)
+				}
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply all reductions over random range with random
+		// bits of random width, including max integers for sums,
+		// and compare the result with the same big.Int reductions.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 13, 63, 64, 65, 100, 128, 129, 200}[rnd.Int()%10], rnd.Int()%300+1
+			from := rnd.Int() % l
+			to := from + rnd.Int()%(l-from) + 1
+			vint := h.NewVarInt(blen, l)
+			slice := make([]*big.Int, 0, l)
+			max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(blen)), big.NewInt(1))
+			full := rnd.Int()%4 == 0
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				if full {
+					h.VarIntSet(i, NewBitsBigInt(max))
+				}
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			amin, amax := from, from
+			or, and, xor, sum := new(big.Int), new(big.Int).Set(max), new(big.Int), new(big.Int)
+			for i := from; i < to; i++ {
+				if slice[i].Cmp(slice[amin]) < 0 {
+					amin = i
+				}
+				if slice[i].Cmp(slice[amax]) > 0 {
+					amax = i
+				}
+				or.Or(or, slice[i])
+				and.And(and, slice[i])
+				xor.Xor(xor, slice[i])
+				sum.Add(sum, slice[i])
+			}
+			bits := NewBits(blen, nil)
+			h.NoError(vint.Min(from, to, bits))
+			h.Equal(bits, NewBitsBits(blen, NewBitsBigInt(slice[amin])))
+			h.NoError(vint.Max(from, to, bits))
+			h.Equal(bits, NewBitsBits(blen, NewBitsBigInt(slice[amax])))
+			arg, err := vint.ArgMin(from, to)
+			h.NoError(err)
+			h.Equal(arg, amin)
+			arg, err = vint.ArgMax(from, to)
+			h.NoError(err)
+			h.Equal(arg, amax)
+			h.NoError(vint.OrAll(from, to, bits))
+			h.Equal(bits, NewBitsBits(blen, NewBitsBigInt(or)))
+			h.NoError(vint.AndAll(from, to, bits))
+			h.Equal(bits, NewBitsBits(blen, NewBitsBigInt(and)))
+			h.NoError(vint.XorAll(from, to, bits))
+			h.Equal(bits, NewBitsBits(blen, NewBitsBigInt(xor)))
+			sbits := NewBits(blen+math_bits.Len(uint(to-from-1)), nil)
+			h.NoError(vint.Sum(from, to, sbits))
+			h.Equal(sbits, NewBitsBits(sbits.BitLen(), NewBitsBigInt(sum)))
+			if to-from > 1 {
+				h.Equal(vint.Sum(from, to, NewBits(sbits.BitLen()-1, nil)), ErrorUnequalBitLengthCardinality)
+			}
+		}
+	})
+	test("Allocs", t, func(h h) {
+		// Reductions don't allocate any new memory.
+		vint, bits, sbits := h.NewVarInt(100, 1000), NewBits(100, nil), NewBits(110, nil)
+		allocs := testing.AllocsPerRun(10, func() {
+			_ = vint.Min(0, 1000, bits)
+			_, _ = vint.ArgMax(0, 1000)
+			_ = vint.XorAll(0, 1000, bits)
+			_ = vint.Sum(0, 1000, sbits)
+		})
+		h.Equal(allocs, 0.0)
+	})
+}
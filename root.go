@@ -0,0 +1,107 @@
+package varint
+
+import math_bits "math/bits"
+
+// Sqrt sets the integer inside VarInt at the provided index to its integer square root floor(sqrt(x)).
+// It's a shortcut for Root operation with degree 2, see Root for more details.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) Sqrt(i int) error {
+	return vint.Root(i, 2)
+}
+
+// Root sets the integer inside VarInt at the provided index to its integer n-th root floor(x^(1/n))
+// using Newton iteration. Each iteration relies on the same word level long division as Div, and the
+// iteration state is kept either inside the collocated Karatsuba scratch words for wide integers or
+// inside a fixed size array on stack for narrow integers, so Root doesn't allocate any new memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided degree n is not positive, ErrorDegreeIsNotPositive is returned.
+func (vint VarInt) Root(i, n int) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	if n <= 0 {
+		return ErrorDegreeIsNotPositive
+	}
+	return vint.root(i, n)
+}
+
+// root internal core of Root operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) root(i, n int) error {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	// Find the number of the integer significant bits.
+	bl := vint.nbits(i)
+	// Trivial roots don't need any iteration, note that for
+	// any degree not lower than the number of the integer
+	// significant bits the root is always 1.
+	switch {
+	case bl == 0 || n == 1:
+		return nil
+	case n >= bl:
+		for k := 1; k < words; k++ {
+			wset(vint, i, k, 0)
+		}
+		wset(vint, i, 0, 1)
+		return nil
+	}
+	// Keep the current root estimation x and the next root estimation y
+	// inside the scratch words as Bits operands, both estimations are
+	// never greater than the integer itself, so they fit into the bit len.
+	var stack [2 * (2*kthreshold + 1)]uint
+	t := stack[:]
+	if kv := kvar(vint); kv != nil {
+		t = kv
+	}
+	x, y := operand{bits: Bits(t[:words+1]), blen: blen}, operand{bits: Bits(t[words+1 : 2*words+2]), blen: blen}
+	xb, yb := x.bits[1:], y.bits[1:]
+	// Start from the power of 2 that is always greater than the root.
+	for k := 0; k < words; k++ {
+		xb[k] = 0
+	}
+	e := (bl + n - 1) / n
+	xb[e/wsize] = 1 << (e % wsize)
+	r, a, un := bvar(vint, true).Bytes(), operand{vint: vint, j: i}, uint(n)
+	for {
+		// Calculate the integer quotient a/x^(n-1) with consecutive
+		// divisions by x, as nested integer quotients are equal to it.
+		wdiv(a, x, y, r, true)
+		for k := 2; k < n; k++ {
+			wdiv(y, x, y, r, true)
+		}
+		// Calculate the next estimation y = ((n-1)*x + a/x^(n-1))/n,
+		// the carry word of the sum is always lower than n.
+		var carry uint
+		for k := 0; k < words; k++ {
+			var c1, c2 uint
+			hi, lo := math_bits.Mul(xb[k], un-1)
+			lo, c1 = math_bits.Add(lo, carry, 0)
+			yb[k], c2 = math_bits.Add(lo, yb[k], 0)
+			carry = hi + c1 + c2
+		}
+		for k := words - 1; k >= 0; k-- {
+			yb[k], carry = math_bits.Div(carry, yb[k], un)
+		}
+		// Stop as soon as the estimation doesn't decrease anymore,
+		// the current estimation x is the integer root then.
+		ge := true
+		for k := words - 1; k >= 0; k-- {
+			if yb[k] != xb[k] {
+				ge = yb[k] > xb[k]
+				break
+			}
+		}
+		if ge {
+			break
+		}
+		copy(xb, yb)
+	}
+	for k := 0; k < words; k++ {
+		wset(vint, i, k, xb[k])
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntRootOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i    int
+			n    int
+			err  error
+		}{
+			"root operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				n:    2,
+				err:  ErrorVarIntIsInvalid,
+			},
+			"root operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    -1,
+				n:    2,
+				err:  ErrorIndexIsNegative,
+			},
+			"root operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				n:    2,
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"root operation should return not positive degree error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				n:    0,
+				err:  ErrorDegreeIsNotPositive,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				if tcase.n == 2 {
+					h.Equal(tcase.vint.Sqrt(tcase.i), tcase.err)
+				}
+				h.Equal(tcase.vint.Root(tcase.i, tcase.n), tcase.err)
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Apply Sqrt and Root with random degrees and check that
+		// the result r satisfies r^n <= x < (r+1)^n with big.Int.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 7, 63, 64, 65, 128, 129, 200, 521, 5000}[rnd.Int()%10], rnd.Int()%4+1
+			i := rnd.Int() % l
+			vint := h.NewVarInt(blen, l)
+			for x := 0; x < l; x++ {
+				h.VarIntSet(x, NewBitsRand(blen, rnd))
+			}
+			// Make sure perfect powers are checked as well.
+			n := []int{1, 2, 2, 3, 5, 17, 100}[rnd.Int()%7]
+			if rnd.Int()%4 == 0 {
+				b := NewBitsRand(blen/n+1, rnd).BigInt()
+				b.Exp(b, big.NewInt(int64(n)), nil)
+				if b.BitLen() <= blen {
+					h.VarIntSet(i, NewBitsBits(blen, NewBitsBigInt(b)))
+				}
+			}
+			a := h.VarIntGet(i).BigInt()
+			if n == 2 && rnd.Int()%2 == 0 {
+				h.NoError(vint.Sqrt(i))
+			} else {
+				h.NoError(vint.Root(i, n))
+			}
+			r := h.VarIntGet(i).BigInt()
+			bn := big.NewInt(int64(n))
+			h.Equal(new(big.Int).Exp(r, bn, nil).Cmp(a) <= 0, true)
+			r.Add(r, big.NewInt(1))
+			h.Equal(new(big.Int).Exp(r, bn, nil).Cmp(a) > 0, true)
+		}
+	})
+	test("Allocs", t, func(h h) {
+		// Roots of both narrow and wide integers
+		// don't allocate any new memory.
+		for _, blen := range []int{100, 2 * kthreshold * wsize} {
+			vint, bits := h.NewVarInt(blen, len), NewBitsRand(blen, rnd)
+			allocs := testing.AllocsPerRun(10, func() {
+				_ = vint.Set(1, bits)
+				_ = vint.Root(1, 3)
+			})
+			h.Equal(allocs, 0.0)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package varint
+
+// AddSat adds the provided bits to the integer inside VarInt at the provided index with saturation.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the addition result overflows the bit len, instead of wrapping around the integer
+// is clamped to its max value 2^blen-1 and extra ErrorAdditionOverflow warning is returned.
+// The saturation is applied regardless of VarInt Overflow policy.
+func (vint VarInt) AddSat(i int, bits Bits) error {
+	if err := vint.cbits(i, bits); err != nil {
+		return err
+	}
+	return vint.sat(i, vint.add(i, bits))
+}
+
+// SubSat subtracts the provided bits from the integer inside VarInt at the provided index with saturation.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the subtraction result underflows the integer, instead of wrapping around the integer
+// is clamped to its min value 0 and extra ErrorSubtractionUnderflow warning is returned.
+// The saturation is applied regardless of VarInt Overflow policy.
+func (vint VarInt) SubSat(i int, bits Bits) error {
+	if err := vint.cbits(i, bits); err != nil {
+		return err
+	}
+	return vint.sat(i, vint.sub(i, bits))
+}
+
+// MulSat multiplies the provided bits with the integer inside VarInt at the provided index with saturation.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the multiplication result overflows the bit len, instead of truncation the integer
+// is clamped to its max value 2^blen-1 and extra ErrorMultiplicationOverflow warning is returned.
+// The saturation is applied regardless of VarInt Overflow policy.
+func (vint VarInt) MulSat(i int, bits Bits) error {
+	if err := vint.cbits(i, bits); err != nil {
+		return err
+	}
+	return vint.sat(i, vint.mul(i, bits))
+}
+
+// sat internal helper that clamps the integer inside VarInt at the provided index
+// accordingly to the provided arithmetic warning, the warning itself is returned back.
+// On overflow the integer is clamped to max value, on underflow to min value.
+func (vint VarInt) sat(i int, err error) error {
+	var w uint
+	switch err {
+	case ErrorAdditionOverflow, ErrorMultiplicationOverflow:
+		w = ^uint(0)
+	case ErrorSubtractionUnderflow:
+		w = 0
+	default:
+		return err
+	}
+	for k, words := 0, (BitLen(vint)+wsize-1)/wsize; k < words; k++ {
+		wset(vint, i, k, w)
+	}
+	return err
+}
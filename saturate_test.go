@@ -0,0 +1,120 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestVarIntSaturatedOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			i    int
+			bits Bits
+			err  error
+		}{
+			"saturated operations should return invalid varint error": {
+				vint: nil,
+				i:    1,
+				bits: NewBits(len, nil),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"saturated operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				i:    -1,
+				bits: NewBits(len, nil),
+				err:  ErrorIndexIsNegative,
+			},
+			"saturated operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				i:    len,
+				bits: NewBits(len, nil),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"saturated operations should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				i:    1,
+				bits: NewBits(2*len, nil),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(tcase.vint.AddSat(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.SubSat(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.vint.MulSat(tcase.i, tcase.bits), tcase.err)
+			})
+		}
+	})
+	test("Counters", t, func(h h) {
+		// Small width counters should stick
+		// to their bounds and never reset.
+		vint := h.NewVarInt(4, len)
+		for k := 0; k < 20; k++ {
+			err := vint.AddSat(1, NewBits(4, []uint{1}))
+			if k < 15 {
+				h.NoError(err)
+			} else {
+				h.Equal(err, ErrorAdditionOverflow)
+			}
+		}
+		h.VarIntEqual(1, NewBits(4, []uint{15}))
+		h.Equal(vint.MulSat(1, NewBits(4, []uint{2})), ErrorMultiplicationOverflow)
+		h.VarIntEqual(1, NewBits(4, []uint{15}))
+		h.Equal(vint.SubSat(1, NewBits(4, []uint{10})), nil)
+		h.Equal(vint.SubSat(1, NewBits(4, []uint{10})), ErrorSubtractionUnderflow)
+		h.VarIntEqual(1, NewBits(4, nil))
+		h.VarIntEqual(0, NewBits(4, nil))
+		h.VarIntEqual(2, NewBits(4, nil))
+	})
+	test("Rand", t, func(h h) {
+		// Apply random saturated operation and compare the result
+		// and the warning with the same clamped big.Int operation.
+		for k := 0; k < 500; k++ {
+			blen, l := []int{1, 4, 7, 63, 64, 65, 100, 128, 129, 200}[rnd.Int()%10], rnd.Int()%10+1
+			i := rnd.Int() % l
+			vint := h.NewVarInt(blen, l)
+			for x := 0; x < l; x++ {
+				_ = vint.Set(x, NewBitsRand(blen, rnd))
+			}
+			bits := NewBitsRand(blen, rnd)
+			if rnd.Int()%2 == 0 {
+				bits = NewBits(blen, []uint{uint(rnd.Int() % (blen + 2))})
+			}
+			vintc := append(VarInt(nil), vint...)
+			ai := h.VarIntGet(i)
+			lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+			max := new(big.Int).Sub(lim, big.NewInt(1))
+			a, b, r := ai.BigInt(), bits.BigInt(), new(big.Int)
+			var err, expected error
+			switch rnd.Int() % 3 {
+			case 0:
+				if r.Add(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorAdditionOverflow
+					r.Set(max)
+				}
+				err = vint.AddSat(i, bits)
+			case 1:
+				if r.Sub(a, b); r.Sign() < 0 {
+					expected = ErrorSubtractionUnderflow
+					r.SetInt64(0)
+				}
+				err = vint.SubSat(i, bits)
+			default:
+				if r.Mul(a, b); r.Cmp(lim) >= 0 {
+					expected = ErrorMultiplicationOverflow
+					r.Set(max)
+				}
+				err = vint.MulSat(i, bits)
+			}
+			h.Equal(err, expected)
+			h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(r)))
+			// Restore the changed integer and check that
+			// all other integers are left unchanged.
+			h.VarIntSet(i, ai)
+			_, _ = bvar(vint, true), bvar(vintc, true)
+			h.Equal(vint, vintc)
+		}
+	})
+}
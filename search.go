@@ -0,0 +1,117 @@
+package varint
+
+// Search binary searches the provided bits inside the provided VarInt sorted in ascending order
+// and returns the index where the bits is found or would be inserted, and true if the bits is found.
+// It compares the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func Search(vint VarInt, bits Bits) (int, bool, error) {
+	if err := csearch(vint, bits); err != nil {
+		return 0, false, err
+	}
+	x := operand{bits: bits, blen: BitLen(vint)}
+	i := lbound(vint, x)
+	return i, i < Len(vint) && vint.cmpv(i, x) == 0, nil
+}
+
+// LowerBound binary searches the provided bits inside the provided VarInt sorted in ascending order
+// and returns the index of the first integer that is not lower than the bits, or len of VarInt if there is none.
+// It compares the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func LowerBound(vint VarInt, bits Bits) (int, error) {
+	if err := csearch(vint, bits); err != nil {
+		return 0, err
+	}
+	return lbound(vint, operand{bits: bits, blen: BitLen(vint)}), nil
+}
+
+// UpperBound binary searches the provided bits inside the provided VarInt sorted in ascending order
+// and returns the index of the first integer that is greater than the bits, or len of VarInt if there is none.
+// It compares the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func UpperBound(vint VarInt, bits Bits) (int, error) {
+	if err := csearch(vint, bits); err != nil {
+		return 0, err
+	}
+	return ubound(vint, operand{bits: bits, blen: BitLen(vint)}), nil
+}
+
+// EqualRange binary searches the provided bits inside the provided VarInt sorted in ascending order
+// and returns the range [from, to) of all the integers that are equal to the bits, the range is empty if there is none.
+// It's the combination of LowerBound and UpperBound, see them for more details.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+func EqualRange(vint VarInt, bits Bits) (int, int, error) {
+	if err := csearch(vint, bits); err != nil {
+		return 0, 0, err
+	}
+	x := operand{bits: bits, blen: BitLen(vint)}
+	return lbound(vint, x), ubound(vint, x), nil
+}
+
+// SearchFunc binary searches the provided VarInt and returns the index of the first integer for which
+// the provided predicate is true, or len of VarInt if there is none, akin to sort.Search. The predicate
+// has to be false for some prefix of VarInt and true for the rest of it. The predicate receives the temporary
+// Bits variable collocated on VarInt itself, so the Bits variable must not be retained or mutated by the predicate,
+// and SearchFunc doesn't allocate any new memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+func SearchFunc(vint VarInt, pred func(bits Bits) bool) (int, error) {
+	if vint == nil {
+		return 0, ErrorVarIntIsInvalid
+	}
+	b := bvar(vint, false)
+	lo, hi := 0, Len(vint)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		_ = vint.get(m, b)
+		if pred(b) {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return lo, nil
+}
+
+// csearch internal helper that validates
+// the provided VarInt and bits for search.
+func csearch(vint VarInt, bits Bits) error {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return ErrorVarIntIsInvalid
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	return nil
+}
+
+// lbound internal helper that returns the index of
+// the first integer that is not lower than the operand.
+func lbound(vint VarInt, x operand) int {
+	lo, hi := 0, Len(vint)
+	for lo < hi {
+		if m := int(uint(lo+hi) >> 1); vint.cmpv(m, x) < 0 {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return lo
+}
+
+// ubound internal helper that returns the index of
+// the first integer that is greater than the operand.
+func ubound(vint VarInt, x operand) int {
+	lo, hi := 0, Len(vint)
+	for lo < hi {
+		if m := int(uint(lo+hi) >> 1); vint.cmpv(m, x) <= 0 {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return lo
+}
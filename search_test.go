@@ -0,0 +1,89 @@
+package varint
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			vint VarInt
+			bits Bits
+			err  error
+		}{
+			"search operations should return invalid varint error": {
+				vint: nil,
+				bits: NewBits(len, nil),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"search operations should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				bits: NewBits(2*len, nil),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				_, _, err := Search(tcase.vint, tcase.bits)
+				h.Equal(err, tcase.err)
+				_, err = LowerBound(tcase.vint, tcase.bits)
+				h.Equal(err, tcase.err)
+				_, err = UpperBound(tcase.vint, tcase.bits)
+				h.Equal(err, tcase.err)
+				_, _, err = EqualRange(tcase.vint, tcase.bits)
+				h.Equal(err, tcase.err)
+				// Search func doesn't have any bits to validate.
+				if tcase.err == ErrorVarIntIsInvalid {
+					_, err = SearchFunc(tcase.vint, nil)
+					h.Equal(err, tcase.err)
+				}
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Fill a varint with random bits of random width, including
+		// narrow widths with many duplicated integers, sort it and
+		// search both present and random bits comparing the result
+		// with the same sort.Search over sorted big.Int slice.
+		for k := 0; k < 300; k++ {
+			blen, l := []int{1, 3, 7, 13, 40, 63, 64, 65, 100, 129, 200}[rnd.Int()%11], rnd.Int()%1000+1
+			vint := h.NewVarInt(blen, l)
+			slice := make([]*big.Int, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			sort.Sort(Sortable(vint))
+			sort.Slice(slice, func(i, j int) bool {
+				return slice[i].Cmp(slice[j]) < 0
+			})
+			bits := NewBitsRand(blen, rnd)
+			if rnd.Int()%2 == 0 {
+				bits = h.VarIntGet(rnd.Int() % l)
+			}
+			b := bits.BigInt()
+			lower := sort.Search(l, func(i int) bool { return slice[i].Cmp(b) >= 0 })
+			upper := sort.Search(l, func(i int) bool { return slice[i].Cmp(b) > 0 })
+			i, found, err := Search(vint, bits)
+			h.NoError(err)
+			h.Equal(i, lower)
+			h.Equal(found, lower < upper)
+			i, err = LowerBound(vint, bits)
+			h.NoError(err)
+			h.Equal(i, lower)
+			i, err = UpperBound(vint, bits)
+			h.NoError(err)
+			h.Equal(i, upper)
+			from, to, err := EqualRange(vint, bits)
+			h.NoError(err)
+			h.Equal(from, lower)
+			h.Equal(to, upper)
+			i, err = SearchFunc(vint, func(bits Bits) bool { return bits.BigInt().Cmp(b) > 0 })
+			h.NoError(err)
+			h.Equal(i, upper)
+		}
+	})
+}
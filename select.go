@@ -0,0 +1,194 @@
+package varint
+
+import math_bits "math/bits"
+
+// NthElement partially sorts the provided VarInt in place with introselect method, so that the integer at
+// the provided index k is the same integer that would be there if VarInt was fully sorted in ascending order.
+// All the integers before the index k are not greater than it, and all the integers after it are not lower than it.
+// It compares and swaps the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func NthElement(vint VarInt, k int) error {
+	if err := vint.cany(k); err != nil {
+		return err
+	}
+	sselect(sortable{vint: vint}, 0, Len(vint), k)
+	return nil
+}
+
+// Median partially sorts the provided VarInt in place the same way as NthElement does
+// and returns the index of the lower median integer, which is (Len - 1) / 2.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+func Median(vint VarInt) (int, error) {
+	if vint == nil {
+		return 0, ErrorVarIntIsInvalid
+	}
+	k := (Len(vint) - 1) / 2
+	sselect(sortable{vint: vint}, 0, Len(vint), k)
+	return k, nil
+}
+
+// PartialSort partially sorts the provided VarInt in place, so that the first k integers
+// are the k lowest integers sorted in ascending order, the rest integers are left in unspecified order.
+// It compares and swaps the packed words directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided k is negative or greater than len of VarInt, ErrorLengthIsOutOfRange is returned.
+func PartialSort(vint VarInt, k int) error {
+	if vint == nil {
+		return ErrorVarIntIsInvalid
+	}
+	if length := Len(vint); k < 0 || k > length {
+		return ErrorLengthIsOutOfRange
+	}
+	if k == 0 {
+		return nil
+	}
+	s := sortable{vint: vint}
+	sselect(s, 0, Len(vint), k-1)
+	sheap(s, 0, k)
+	return nil
+}
+
+// TopK sets the first k integers of the provided dst VarInt to the k greatest integers of the provided VarInt
+// sorted in descending order, the rest dst integers are left unchanged. The provided VarInt is never mutated,
+// the k greatest integers are collected into the min heap inside dst directly, so TopK doesn't require
+// any Bits variable and doesn't allocate any memory. As the heap is built inside dst, dst can't be VarInt itself.
+// In case the operation is used on invalid nil VarInt or nil dst, ErrorVarIntIsInvalid is returned.
+// In case the provided dst shares the same numeric bytes slice with VarInt, ErrorVarIntIsInvalid is returned.
+// In case the provided dst has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the provided k is negative or greater than len of VarInt or dst, ErrorLengthIsOutOfRange is returned.
+func TopK(vint VarInt, k int, dst VarInt) error {
+	if vint == nil || dst == nil {
+		return ErrorVarIntIsInvalid
+	}
+	// Check that destination doesn't share the
+	// same numeric bytes slice with the source.
+	if &dst[0] == &vint[0] {
+		return ErrorVarIntIsInvalid
+	}
+	if BitLen(vint) != BitLen(dst) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	length := Len(vint)
+	if k < 0 || k > length || k > Len(dst) {
+		return ErrorLengthIsOutOfRange
+	}
+	if k == 0 {
+		return nil
+	}
+	// Keep the min heap of the k greatest integers seen so far,
+	// reversed comparison turns the max heap helpers into the min heap.
+	h := sortable{vint: dst}
+	for i := 0; i < k; i++ {
+		dst.movev(i, vint, i)
+	}
+	for i := k/2 - 1; i >= 0; i-- {
+		ssift(h, i, 0, k, true)
+	}
+	for i := k; i < length; i++ {
+		if dst.cmpv(0, operand{vint: vint, j: i}) < 0 {
+			dst.movev(0, vint, i)
+			ssift(h, 0, 0, k, true)
+		}
+	}
+	// Pop the min heap from the back, so
+	// the integers end up in descending order.
+	for i := k - 1; i > 0; i-- {
+		h.Swap(0, i)
+		ssift(h, 0, 0, i, true)
+	}
+	return nil
+}
+
+// sselect internal helper that rearranges the provided sortable in range [lo, hi) with introselect method,
+// so that the k-th integer is in its sorted position. It uses quickselect with median of three pivot
+// and falls back to heap sort of the remaining range, once the quickselect is too deep.
+func sselect(s sortable, lo, hi, k int) {
+	for depth := 2 * math_bits.Len(uint(hi-lo)); hi-lo > 12; depth-- {
+		if depth == 0 {
+			sheap(s, lo, hi)
+			return
+		}
+		switch p := spart(s, lo, hi); {
+		case k == p:
+			return
+		case k < p:
+			hi = p
+		default:
+			lo = p + 1
+		}
+	}
+	// Finish short ranges with insertion sort.
+	for i := lo + 1; i < hi; i++ {
+		for j := i; j > lo && s.Less(j, j-1); j-- {
+			s.Swap(j, j-1)
+		}
+	}
+}
+
+// spart internal helper that partitions the provided sortable in range [lo, hi) around the median of three pivot
+// and returns the pivot final index, the integers equal to the pivot are spread on both sides of it.
+func spart(s sortable, lo, hi int) int {
+	// Order the first, the middle and the last integers,
+	// then move the median of them to the first index.
+	m, l := lo+(hi-lo)/2, hi-1
+	if s.Less(m, lo) {
+		s.Swap(m, lo)
+	}
+	if s.Less(l, m) {
+		s.Swap(l, m)
+		if s.Less(m, lo) {
+			s.Swap(m, lo)
+		}
+	}
+	s.Swap(lo, m)
+	i, j := lo+1, l
+	for {
+		for i <= j && s.Less(i, lo) {
+			i++
+		}
+		for i <= j && s.Less(lo, j) {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		s.Swap(i, j)
+		i, j = i+1, j-1
+	}
+	s.Swap(lo, j)
+	return j
+}
+
+// sheap internal helper that sorts the provided sortable
+// in range [lo, hi) in ascending order with heap sort method.
+func sheap(s sortable, lo, hi int) {
+	n := hi - lo
+	for i := n/2 - 1; i >= 0; i-- {
+		ssift(s, i, lo, n, false)
+	}
+	for i := n - 1; i > 0; i-- {
+		s.Swap(lo, lo+i)
+		ssift(s, 0, lo, i, false)
+	}
+}
+
+// ssift internal helper that sifts down the provided heap root inside the provided sortable max heap
+// of the provided size that starts at the provided offset, in case of rev it's the min heap instead.
+func ssift(s sortable, root, lo, n int, rev bool) {
+	for {
+		child := 2*root + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && s.Less(lo+child, lo+child+1) != rev {
+			child++
+		}
+		if s.Less(lo+root, lo+child) == rev {
+			return
+		}
+		s.Swap(lo+root, lo+child)
+		root = child
+	}
+}
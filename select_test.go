@@ -0,0 +1,151 @@
+package varint
+
+import (
+	"math/big"
+	"sort"
+	"testing"
+)
+
+func TestSelect(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		alias := th.NewVarInt(len, len)
+		table := map[string]struct {
+			vint VarInt
+			k    int
+			dst  VarInt
+			err  error
+		}{
+			"select operations should return invalid varint error": {
+				vint: nil,
+				k:    1,
+				dst:  th.NewVarInt(len, len),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"select operations should return invalid varint error on nil dst": {
+				vint: th.NewVarInt(len, len),
+				k:    1,
+				dst:  nil,
+				err:  ErrorVarIntIsInvalid,
+			},
+			"select operations should return invalid varint error on dst aliasing varint": {
+				vint: alias,
+				k:    1,
+				dst:  alias,
+				err:  ErrorVarIntIsInvalid,
+			},
+			"select operations should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				k:    -1,
+				dst:  th.NewVarInt(len, len),
+				err:  ErrorIndexIsNegative,
+			},
+			"select operations should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				k:    len + 1,
+				dst:  th.NewVarInt(len, len),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"select operations should return bit len cardinarity error": {
+				vint: th.NewVarInt(len, len),
+				k:    1,
+				dst:  th.NewVarInt(2*len, len),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+			"select operations should return length is out of range error on short dst": {
+				vint: th.NewVarInt(len, len),
+				k:    len / 2,
+				dst:  th.NewVarInt(len, len/2-1),
+				err:  ErrorLengthIsOutOfRange,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				switch tcase.err {
+				case ErrorVarIntIsInvalid:
+					h.Equal(TopK(tcase.vint, tcase.k, tcase.dst), tcase.err)
+					if tcase.vint == nil {
+						h.Equal(NthElement(tcase.vint, tcase.k), tcase.err)
+						h.Equal(PartialSort(tcase.vint, tcase.k), tcase.err)
+						_, err := Median(tcase.vint)
+						h.Equal(err, tcase.err)
+					}
+				case ErrorIndexIsNegative, ErrorIndexIsOutOfRange:
+					// Partial sort and top k operations
+					// validate k as the number of integers.
+					h.Equal(NthElement(tcase.vint, tcase.k), tcase.err)
+					h.Equal(PartialSort(tcase.vint, tcase.k), ErrorLengthIsOutOfRange)
+					h.Equal(TopK(tcase.vint, tcase.k, tcase.dst), ErrorLengthIsOutOfRange)
+				default:
+					h.Equal(TopK(tcase.vint, tcase.k, tcase.dst), tcase.err)
+				}
+			})
+		}
+	})
+	test("Rand", t, func(h h) {
+		// Fill a varint with random bits of random width, including
+		// narrow widths with many duplicated integers, apply random
+		// selection operation and verify the result with sorted big.Int slice.
+		for k := 0; k < 300; k++ {
+			blen, l := []int{1, 3, 7, 13, 40, 63, 64, 65, 100, 129, 200}[rnd.Int()%11], rnd.Int()%1000+1
+			vint := h.NewVarInt(blen, l)
+			slice := make([]*big.Int, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			// Make sure already sorted integers are checked as well.
+			if rnd.Int()%5 == 0 {
+				sort.Sort(Sortable(vint))
+			}
+			sort.Slice(slice, func(i, j int) bool {
+				return slice[i].Cmp(slice[j]) < 0
+			})
+			n := rnd.Int() % (l + 1)
+			switch rnd.Int() % 4 {
+			case 0:
+				n %= l
+				h.NoError(NthElement(vint, n))
+				h.VarIntEqual(n, NewBitsBits(blen, NewBitsBigInt(slice[n])))
+				for i := 0; i < l; i++ {
+					cmp, err := vint.Cmp(i, n)
+					h.NoError(err)
+					h.Equal(i < n && cmp > 0 || i > n && cmp < 0, false)
+				}
+			case 1:
+				m, err := Median(vint)
+				h.NoError(err)
+				h.Equal(m, (l-1)/2)
+				h.VarIntEqual(m, NewBitsBits(blen, NewBitsBigInt(slice[m])))
+			case 2:
+				h.NoError(PartialSort(vint, n))
+				for i := 0; i < n; i++ {
+					h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(slice[i])))
+				}
+			case 3:
+				vintc := append(VarInt(nil), vint...)
+				dst := h.NewVarInt(blen, n+1)
+				h.NoError(TopK(vint, n, dst))
+				h.Equal(vint, vintc)
+				h.VarInt = dst
+				for i := 0; i < n; i++ {
+					h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(slice[l-1-i])))
+				}
+				h.VarIntEqual(n, NewBits(blen, nil))
+			}
+		}
+	})
+	test("Allocs", t, func(h h) {
+		// Selection operations don't allocate any new memory.
+		dst, vint := h.NewVarInt(100, 100), h.NewVarInt(100, 1000)
+		for i := 0; i < 1000; i++ {
+			h.VarIntSet(i, NewBitsRand(100, rnd))
+		}
+		allocs := testing.AllocsPerRun(10, func() {
+			_ = NthElement(vint, 500)
+			_ = PartialSort(vint, 100)
+			_ = TopK(vint, 100, dst)
+		})
+		h.Equal(allocs, 0.0)
+	})
+}
@@ -2,10 +2,11 @@ package varint
 
 // sortable implements sort.Interface on top of VarInt.
 // VarInt doesn't implement sort.Interface directly by choice
-// to make it more consistent and ergonomic.
+// to make it more consistent and ergonomic. Note that Less
+// is built on the same core as Cmp, so it's read-only and
+// only Swap mutates the integers.
 type sortable struct {
 	vint VarInt
-	bits Bits
 }
 
 func (s sortable) Len() int {
@@ -13,14 +14,35 @@ func (s sortable) Len() int {
 }
 
 func (s sortable) Less(i, j int) bool {
-	_ = s.vint.Get(j, s.bits)
-	less := s.vint.Sub(i, s.bits) == ErrorSubtractionUnderflow
-	_ = s.vint.Add(i, s.bits)
-	return less
+	return s.vint.cmpv(i, operand{vint: s.vint, j: j}) < 0
 }
 
 func (s sortable) Swap(i, j int) {
-	_ = s.vint.Get(j, s.bits)
-	_ = s.vint.GetSet(i, s.bits)
-	_ = s.vint.Set(j, s.bits)
+	s.vint.swapv(i, s.vint, j)
+}
+
+// sortablef implements sort.Interface on top of VarInt with the custom comparator.
+// Both compared integers are read into the reserved Bits variable and
+// the second Bits variable respectively before the comparator call.
+// For narrow bit lengths the second Bits variable is kept inside the
+// inline words of the adapter itself, so the adapter is the only allocation.
+type sortablef struct {
+	vint  VarInt
+	a, b  Bits
+	cmp   func(a, b Bits) int
+	words [2 * kthreshold]uint
+}
+
+func (s *sortablef) Len() int {
+	return Len(s.vint)
+}
+
+func (s *sortablef) Less(i, j int) bool {
+	_ = s.vint.get(i, s.a)
+	_ = s.vint.get(j, s.b)
+	return s.cmp(s.a, s.b) < 0
+}
+
+func (s *sortablef) Swap(i, j int) {
+	s.vint.swapv(i, s.vint, j)
 }
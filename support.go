@@ -10,24 +10,162 @@ import (
 // The Bits variable is collocated on VarInt itself, so bvar
 // doesn't allocate any new memory. The reserved Bits variable
 // is appended to the end of any VarInt and used internally for many operations
-// as a computation temporary buffer, including: Mul, Div, Mod, Sort.
+// as a computation temporary buffer, including: Mul, Div, Mod.
 // bvar is standalone function by choice to make VarInt more consistent and ergonomic.
 func bvar(vint VarInt, empty bool) Bits {
 	if vint == nil {
 		return nil
 	}
-	cap := (BitLen(vint)*Len(vint)+wsize-1)/wsize + 2
-	b := Bits(vint[cap:])
+	blen := BitLen(vint)
+	cap := (blen*Len(vint)+wsize-1)/wsize + 2
+	// Calculate number of whole words plus
+	// one word if partial mod word is needed.
+	words := blen/wsize + (blen%wsize+wsize-1)/wsize
+	// Slice the var bits exactly, so any extra space
+	// after the var bits is not affected by them.
+	b := Bits(vint[cap : cap+words+1])
 	if !empty {
 		return b
 	}
 	// Clear var bits state from prev manipulations.
-	for i := 1; i < len(vint)-cap; i++ {
+	for i := 1; i < len(b); i++ {
 		b[i] = 0
 	}
 	return b
 }
 
+// wget internal accessor that returns k-th word of the integer inside VarInt at the provided index.
+// Words are counted from the least significant one akin to Bits words, the most significant
+// word is masked so only the integer bits are returned. It reads the packed words directly,
+// so wget doesn't require any Bits variable and doesn't allocate any new memory.
+func wget(vint VarInt, i, k int) uint {
+	blen := BitLen(vint)
+	// Calculate the ending bit of the k-th word and
+	// number of the integer bits inside the k-th word.
+	return wgetb(vint, blen*(i+1)-1+wsize*2-k*wsize, blen-k*wsize)
+}
+
+// wset internal accessor that sets k-th word of the integer inside VarInt at the provided index.
+// Words are counted from the least significant one akin to Bits words, for the most significant
+// word all the excess bits of the provided word are ignored. It writes the packed words directly,
+// so wset doesn't require any Bits variable and doesn't allocate any new memory.
+func wset(vint VarInt, i, k int, w uint) {
+	blen := BitLen(vint)
+	// Calculate the ending bit of the k-th word and
+	// number of the integer bits inside the k-th word.
+	wsetb(vint, blen*(i+1)-1+wsize*2-k*wsize, blen-k*wsize, w)
+}
+
+// wgetb internal accessor that returns the word of the provided number of bits, capped by word size,
+// that ends at the provided bit inside VarInt. It's the core of wget, that lets sequential operations
+// walk the packed words by moving the ending bit instead of recalculating it from the indexes.
+func wgetb(vint VarInt, bto, n int) uint {
+	if n > wsize {
+		n = wsize
+	}
+	we, oe := bto/wsize, bto%wsize
+	// Combine the right part of the word from the ending vint word
+	// and the left part of the word from the prev vint word, note
+	// that shift by word size results in zero so no extra check is needed.
+	w := vint[we]>>(wsize-1-oe) | vint[we-1]<<(oe+1)
+	return w & (^uint(0) >> (wsize - n))
+}
+
+// wsetb internal accessor that sets the word of the provided number of bits, capped by word size,
+// that ends at the provided bit inside VarInt. It's the core of wset, see wgetb for more details.
+func wsetb(vint VarInt, bto, n int, w uint) {
+	if n > wsize {
+		n = wsize
+	}
+	we, oe := bto/wsize, bto%wsize
+	mask := ^uint(0) >> (wsize - n)
+	// Override the right part of the word inside the ending vint word.
+	m := mask << (wsize - 1 - oe)
+	vint[we] = vint[we]&^m | w<<(wsize-1-oe)&m
+	// Override the left part of the word inside the prev vint word,
+	// only if the word actually crosses the vint words boundary.
+	if n > oe+1 {
+		m = mask >> (oe + 1)
+		vint[we-1] = vint[we-1]&^m | w>>(oe+1)&m
+	}
+}
+
+// wshift internal helper that returns k-th word of the provided words shifted right by the provided shift,
+// negative shift means left shift instead. All words outside of the provided words are treated as zero.
+func wshift(x []uint, s, k int) uint {
+	// Calculate the starting bit of the k-th word
+	// and split it into word index and word offset.
+	p := k*wsize + s
+	q, off := p/wsize, p%wsize
+	if off < 0 {
+		q, off = q-1, off+wsize
+	}
+	var w uint
+	if q >= 0 && q < len(x) {
+		w = x[q] >> off
+	}
+	// Note that shift by word size results
+	// in zero so no extra check is needed.
+	if q+1 >= 0 && q+1 < len(x) {
+		w |= x[q+1] << (wsize - off)
+	}
+	return w
+}
+
+// operand internal helper type that provides uniform word by word access to the operation
+// operand, which is either the integer inside VarInt at the provided index or the provided Bits.
+// Bits operand is zero extended or truncated to the provided bit len on the fly.
+// It's always passed by value, so it doesn't allocate any new memory.
+type operand struct {
+	vint VarInt
+	j    int
+	bits Bits
+	blen int
+}
+
+// word returns k-th word of the operand, words are counted
+// from the least significant one akin to Bits words.
+func (x operand) word(k int) uint {
+	if x.vint != nil {
+		return wget(x.vint, x.j, k)
+	}
+	// Zero extend the narrower bits.
+	if k+1 >= len(x.bits) {
+		return 0
+	}
+	// Truncate the wider bits.
+	w := x.bits[k+1]
+	if n := x.blen - k*wsize; n < wsize {
+		w &= ^uint(0) >> (wsize - n)
+	}
+	return w
+}
+
+// set sets k-th word of the operand, for Bits operand
+// the word is expected to be inside the bits words.
+func (x operand) set(k int, w uint) {
+	if x.vint != nil {
+		wset(x.vint, x.j, k, w)
+		return
+	}
+	x.bits[k+1] = w
+}
+
+// truncated reports whether Bits operand value
+// doesn't fit into the provided bit len.
+func (x operand) truncated() bool {
+	words := (x.blen + wsize - 1) / wsize
+	for k := words; k+1 < len(x.bits); k++ {
+		if x.bits[k+1] != 0 {
+			return true
+		}
+	}
+	if n := x.blen % wsize; n != 0 && words < len(x.bits) {
+		return x.bits[words]>>n != 0
+	}
+	return false
+}
+
 // Len returns length of the VarInt instance.
 // Len is standalone function by choice to make
 // VarInt more consistent and ergonomic.
@@ -47,13 +185,131 @@ func BitLen(vint VarInt) int {
 	if vint == nil {
 		return 0
 	}
-	return int(vint[1])
+	// Exclude overflow policy bits
+	// stored inside the high bits.
+	return int(vint[1] << obits >> obits)
 }
 
 // Sortable returns sort.Interface adapter for provided VarInt
 // that is capable to work with standard sort package.
 func Sortable(vint VarInt) sort.Interface {
-	return sortable{vint: vint, bits: bvar(vint, true)}
+	return sortable{vint: vint}
+}
+
+// SortFunc sorts the provided VarInt in ascending order determined by the provided cmp function,
+// which returns a negative number when a < b, a positive number when a > b and zero when a == b.
+// The cmp function receives temporary Bits variables, the first one is collocated on VarInt itself,
+// so the Bits variables must not be retained or mutated by the cmp function. The sort is not stable.
+// The second Bits variable is collocated on VarInt multiplication scratch for wide bit lengths and kept
+// inside the sort adapter for narrow bit lengths, so SortFunc allocates only the sort.Interface adapter,
+// which is required by sort package and is allocated exactly once per call.
+// It's safe to use on nil VarInt, nothing is sorted.
+func SortFunc(vint VarInt, cmp func(a, b Bits) int) {
+	if Len(vint) < 2 {
+		return
+	}
+	sort.Sort(newsortablef(vint, cmp))
+}
+
+// SortStable sorts the provided VarInt in ascending order determined by the provided cmp function
+// while keeping the original order of equal integers, see SortFunc for more details.
+// It's safe to use on nil VarInt, nothing is sorted.
+func SortStable(vint VarInt, cmp func(a, b Bits) int) {
+	if Len(vint) < 2 {
+		return
+	}
+	sort.Stable(newsortablef(vint, cmp))
+}
+
+// newsortablef internal helper that returns the sort adapter with the custom comparator.
+// The first Bits variable is the temp bits variable, the second one is collocated on
+// Karatsuba multiplication scratch words right after it for wide bit lengths,
+// otherwise it is kept inside the inline words of the adapter.
+func newsortablef(vint VarInt, cmp func(a, b Bits) int) *sortablef {
+	blen := BitLen(vint)
+	words := (blen + wsize - 1) / wsize
+	s := &sortablef{vint: vint, a: bvar(vint, false), cmp: cmp}
+	if kvint := kvar(vint); kvint != nil {
+		s.b = Bits(kvint[:words+1])
+	} else {
+		s.b = Bits(s.words[:words+1])
+	}
+	s.b[0] = uint(blen)
+	return s
+}
+
+// SortDesc sorts the provided VarInt in descending order.
+// It compares the packed words directly, so it doesn't require any Bits variable.
+// It's safe to use on nil VarInt, nothing is sorted.
+func SortDesc(vint VarInt) {
+	sort.Sort(sort.Reverse(Sortable(vint)))
+}
+
+// RadixSort sorts the provided VarInt in ascending order with least significant digit radix sort.
+// The digit size is picked from the bit len, so that all the digits have about the same size not greater
+// than rdigit bits. It reads and moves the packed words directly, but it allocates a scratch VarInt
+// of the same shape and a digit counts slice. It's safe to use on nil VarInt, nothing is sorted.
+func RadixSort(vint VarInt) {
+	length := Len(vint)
+	if length < 2 {
+		return
+	}
+	blen := BitLen(vint)
+	// Split the bit len into the minimal number of passes
+	// and spread the bit len evenly between all the passes.
+	passes := (blen + rdigit - 1) / rdigit
+	d := (blen + passes - 1) / passes
+	svint, _ := NewVarInt(blen, length)
+	counts := make([]int, 1<<d)
+	cap := (blen*length+wsize-1)/wsize + 2
+	src, dst := vint, svint
+	for s := 0; s < blen; s += d {
+		// Count all the digits and skip the pass entirely
+		// if all the integers have the same digit.
+		for k := range counts {
+			counts[k] = 0
+		}
+		for i := 0; i < length; i++ {
+			counts[rdig(src, i, s, d)]++
+		}
+		if counts[rdig(src, 0, s, d)] == length {
+			continue
+		}
+		// Convert the counts into the digits starting positions,
+		// then stable move all the integers into the destination.
+		var pos int
+		for k, c := range counts {
+			counts[k], pos = pos, pos+c
+		}
+		for i := 0; i < length; i++ {
+			dig := rdig(src, i, s, d)
+			dst.movev(counts[dig], src, i)
+			counts[dig]++
+		}
+		src, dst = dst, src
+	}
+	// Copy the packed words back, if the sorted
+	// integers are left inside the scratch VarInt.
+	if &src[0] != &vint[0] {
+		copy(vint[2:cap], src[2:cap])
+	}
+}
+
+// rdigit const max radix sort digit bits size.
+const rdigit = 11
+
+// rdig internal helper that returns the digit of the provided bits size starting
+// from the provided bit of the integer inside VarInt at the provided index.
+func rdig(vint VarInt, i, s, d int) uint {
+	blen := BitLen(vint)
+	k, o := s/wsize, s%wsize
+	w := wget(vint, i, k) >> o
+	// Combine the digit with the next word part,
+	// only if the digit crosses the words boundary.
+	if o+d > wsize && k+1 < (blen+wsize-1)/wsize {
+		w |= wget(vint, i, k+1) << (wsize - o)
+	}
+	return w & (1<<d - 1)
 }
 
 // Encode lazily encodes the provided VarInt into io.ReadCloser.
@@ -146,3 +402,21 @@ func Compare(abits, bbits Bits) int {
 	}
 	return 0
 }
+
+// CompareSigned returns an integer comparing of the provided Bits as two's complement integers.
+// The result is 0 if Bits a == b, -1 if Bits a < b, and +1 Bits if a > b.
+// Currently it only compare bits with the same bit len akin to SVarInt operations,
+// bits with different bit len are compared exactly as Compare does.
+func CompareSigned(abits, bbits Bits) int {
+	// For the same bit len, negative numbers are always smaller,
+	// for numbers with the same sign two's complement preserves unsigned order.
+	if abits.BitLen() == bbits.BitLen() {
+		switch sa, sb := sign(abits), sign(bbits); {
+		case sa && !sb:
+			return -1
+		case !sa && sb:
+			return 1
+		}
+	}
+	return Compare(abits, bbits)
+}
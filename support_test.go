@@ -3,6 +3,7 @@ package varint
 import (
 	"errors"
 	"io"
+	"math/big"
 	"os"
 	"sort"
 	"strings"
@@ -97,6 +98,70 @@ func TestSupport(t *testing.T) {
 			})
 		}
 	})
+	test("BitLenVarExtra", t, func(h h) {
+		// Temp bits variable is sliced exactly, so clearing it
+		// doesn't affect any extra words collocated after it,
+		// like Karatsuba scratch words or SVarInt extra state.
+		for _, blen := range []int{1, 63, 64, 65, 129, 6000} {
+			words := (blen + wsize - 1) / wsize
+			vint := append(h.NewVarInt(blen, 3), 42, 43)
+			b := bvar(vint, true)
+			h.Equal(b.BitLen(), blen)
+			h.Equal(len(b.Bytes()), words)
+			b.Bytes()[words-1] = ^uint(0)
+			_ = bvar(vint, true)
+			h.Equal(b.Empty(), true)
+			h.Equal([]uint(vint[len(vint)-2:]), []uint{42, 43})
+		}
+		svint, _ := NewSVarInt(65, 3)
+		sb := svar(svint, true).Bytes()
+		sb[0], sb[1] = 42, 1
+		_ = bvar(VarInt(svint), true)
+		h.Equal(svar(svint, false), NewBits(65, []uint{42, 1}))
+	})
+	test("CompareSigned", t, func(th h) {
+		table := map[string]struct {
+			abits Bits
+			bbits Bits
+			cmp   int
+		}{
+			"nil bits should be equal": {
+				abits: nil,
+				bbits: nil,
+				cmp:   0,
+			},
+			"negative bits on the left should be smaller": {
+				abits: NewBitsBigIntSigned(100, big.NewInt(-1)),
+				bbits: NewBitsBigIntSigned(100, big.NewInt(1)),
+				cmp:   -1,
+			},
+			"negative bits on the right should be smaller": {
+				abits: NewBitsBigIntSigned(100, big.NewInt(0)),
+				bbits: NewBitsBigIntSigned(100, big.NewInt(-100)),
+				cmp:   1,
+			},
+			"negative bits on the left should be bigger": {
+				abits: NewBitsBigIntSigned(100, big.NewInt(-1)),
+				bbits: NewBitsBigIntSigned(100, big.NewInt(-2)),
+				cmp:   1,
+			},
+			"negative bits with same bit len size should be equal": {
+				abits: NewBitsBigIntSigned(10, big.NewInt(-512)),
+				bbits: NewBitsBigIntSigned(10, big.NewInt(-512)),
+				cmp:   0,
+			},
+			"negative bits with different bit len size should not be equal": {
+				abits: NewBitsBigIntSigned(10, big.NewInt(-5)),
+				bbits: NewBitsBigIntSigned(12, big.NewInt(-5)),
+				cmp:   -1,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.Equal(CompareSigned(tcase.abits, tcase.bbits), tcase.cmp)
+			})
+		}
+	})
 }
 
 func TestSortable(t *testing.T) {
@@ -125,12 +190,127 @@ func TestSortable(t *testing.T) {
 			h.Equal(Compare(bi, bj) >= 0, true)
 		}
 	})
+	test("ReadOnly", t, func(h h) {
+		// Fill a varint with 100 random bits, compare
+		// all pairs of them with less and verify that
+		// varint is not mutated, including tmp bits.
+		const len = 100
+		vint := h.NewVarInt(len, len)
+		for i := 0; i < len; i++ {
+			h.VarIntSet(i, NewBitsRand(len, rnd))
+		}
+		vintc := append(VarInt(nil), vint...)
+		s := Sortable(vint)
+		for i := 0; i < len; i++ {
+			for j := 0; j < len; j++ {
+				cmp, err := vint.Cmp(i, j)
+				h.NoError(err)
+				h.Equal(s.Less(i, j), cmp < 0)
+			}
+		}
+		h.Equal(vint, vintc)
+	})
 	test("Error", t, func(h h) {
 		// Should not panic for nil varint.
 		sort.Sort(Sortable(nil))
 	})
 }
 
+func TestRadixSort(t *testing.T) {
+	test("Rand", t, func(h h) {
+		// Fill a varint with random bits of random
+		// width, including duplicated integers,
+		// sort its copy with sortable and verify
+		// that radix sort produces the same order.
+		for k := 0; k < 100; k++ {
+			blen, l := []int{1, 7, 10, 13, 22, 40, 63, 64, 65, 100, 129, 200}[rnd.Int()%12], rnd.Int()%1000+1
+			vint := h.NewVarInt(blen, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				if i > 0 && rnd.Int()%4 == 0 {
+					h.VarIntSet(i, h.VarIntGet(rnd.Int()%i))
+				}
+			}
+			vintc := append(VarInt(nil), vint...)
+			sort.Sort(Sortable(vintc))
+			RadixSort(vint)
+			h.Equal(vint, vintc)
+		}
+	})
+	test("Error", t, func(h h) {
+		// Should not panic for nil varint.
+		RadixSort(nil)
+	})
+}
+
+func TestSortFunc(t *testing.T) {
+	test("Rand", t, func(h h) {
+		// Fill a varint with random bits of random width,
+		// sort it by the derived key of low bits with all
+		// sorts and verify the order using big.Int slice,
+		// stable sort has to produce exactly the same order.
+		for k := 0; k < 100; k++ {
+			blen, l := []int{1, 7, 13, 40, 63, 64, 65, 100, 129, 200, 6000}[rnd.Int()%11], rnd.Int()%500+1
+			vint := h.NewVarInt(blen, l)
+			slice := make([]*big.Int, 0, l)
+			for i := 0; i < l; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+				slice = append(slice, h.VarIntGet(i).BigInt())
+			}
+			low := uint(rnd.Int()%blen + 1)
+			mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), low), big.NewInt(1))
+			key := func(a *big.Int) *big.Int {
+				return new(big.Int).And(a, mask)
+			}
+			cmp := func(a, b Bits) int {
+				return key(a.BigInt()).Cmp(key(b.BigInt()))
+			}
+			if rnd.Int()%2 == 0 {
+				sort.SliceStable(slice, func(i, j int) bool {
+					return key(slice[i]).Cmp(key(slice[j])) < 0
+				})
+				SortStable(vint, cmp)
+				for i := 0; i < l; i++ {
+					h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(slice[i])))
+				}
+				continue
+			}
+			SortFunc(vint, cmp)
+			for i := 0; i < l-1; i++ {
+				h.Equal(key(h.VarIntGet(i).BigInt()).Cmp(key(h.VarIntGet(i+1).BigInt())) <= 0, true)
+			}
+			SortDesc(vint)
+			sort.Slice(slice, func(i, j int) bool {
+				return slice[i].Cmp(slice[j]) > 0
+			})
+			for i := 0; i < l; i++ {
+				h.VarIntEqual(i, NewBitsBits(blen, NewBitsBigInt(slice[i])))
+			}
+		}
+	})
+	test("Allocs", t, func(h h) {
+		// Custom sorts allocate only the sort adapter
+		// for both narrow and wide bit lengths.
+		for _, blen := range []int{7, 100, (2*kthreshold - 1) * wsize, 6000} {
+			vint := h.NewVarInt(blen, 100)
+			for i := 0; i < 100; i++ {
+				h.VarIntSet(i, NewBitsRand(blen, rnd))
+			}
+			allocs := testing.AllocsPerRun(10, func() {
+				SortFunc(vint, Compare)
+				SortStable(vint, Compare)
+			})
+			h.Equal(allocs, 2.0)
+		}
+	})
+	test("Error", t, func(h h) {
+		// Should not panic for nil varint.
+		SortFunc(nil, nil)
+		SortStable(nil, nil)
+		SortDesc(nil)
+	})
+}
+
 func TestEncodeDecode(t *testing.T) {
 	test("Rand", t, func(h h) {
 		// Fill a varint with 100 random bits,
@@ -202,6 +382,28 @@ func BenchmarkVarIntSupport(b *testing.B) {
 		b.ResetTimer()
 		sort.Sort(Sortable(vint))
 	})
+	bench("Benchmark VarInt SortFunc", b, func(b *testing.B) {
+		// Shuffle before the sorting and reset timer.
+		for i := 0; i < len; i++ {
+			j := rnd.Int() % len
+			_ = vint.Get(i, bits)
+			_ = vint.GetSet(j, bits)
+			_ = vint.Set(i, bits)
+		}
+		b.ResetTimer()
+		SortFunc(vint, Compare)
+	})
+	bench("Benchmark VarInt RadixSort", b, func(b *testing.B) {
+		// Shuffle before the sorting and reset timer.
+		for i := 0; i < len; i++ {
+			j := rnd.Int() % len
+			_ = vint.Get(i, bits)
+			_ = vint.GetSet(j, bits)
+			_ = vint.Set(i, bits)
+		}
+		b.ResetTimer()
+		RadixSort(vint)
+	})
 	bench("Benchmark VarInt Encode", b, func(b *testing.B) {
 		r := Encode(vint)
 		_, _ = io.Copy(io.Discard, r)
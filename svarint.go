@@ -0,0 +1,325 @@
+package varint
+
+import math_bits "math/bits"
+
+// SVarInt provides fast and memory efficient arbitrary bit length signed integer array type.
+//
+// SVarInt shares the memory layout of VarInt and stores all the integers inside as
+// two's complement integers of the provided bit len, so for n bits len each integer is
+// in range [-2^(n-1), 2^(n-1)-1]. Get, Set, GetSet and all the bitwise operations except Rsh
+// are shared with VarInt, while arithmetic operations and Rsh respect the integer sign.
+// On top of VarInt layout, SVarInt also collocates one extra Bits variable at the very end
+// of numeric bytes slice which is used internally to hold operand magnitude, including: Mul, Div, Mod.
+// Any SVarInt could be converted to VarInt at no cost to reinterpret the integers as unsigned,
+// note however that the opposite conversion is not valid as VarInt doesn't reserve the extra space.
+// Currently, for simplicity and consistency most SVarInt operations apply changes in place on the provided index
+// and require the provided Bits to have exactly the same bit len, otherwise ErrorUnequalBitLengthCardinality is returned.
+type SVarInt []uint
+
+// NewSVarInt allocates and returns SVarInt instance that is capable to
+// fit the provided number of signed integers each of the provided bit len in width.
+// It follows exactly the same rules and returns exactly the same errors and warnings as NewVarInt.
+// See SVarInt type for more details.
+func NewSVarInt(blen, len int) (SVarInt, error) {
+	vint, err := NewVarInt(blen, len)
+	if vint == nil {
+		return nil, err
+	}
+	// Allocate extra protected space at the back for
+	// the extra full bits after the VarInt temp variable.
+	// This temp variable is useful for operations
+	// that require operand magnitude like
+	// multiplication, division or modulo.
+	svint := SVarInt(append(vint, NewBits(blen, nil)...))
+	return svint, err
+}
+
+// Get sets the provided bits to the integer inside SVarInt at the provided index.
+// See VarInt Get for more details.
+func (svint SVarInt) Get(i int, bits Bits) error {
+	return VarInt(svint).Get(i, bits)
+}
+
+// Set sets the provided bits into the integer inside SVarInt at the provided index.
+// See VarInt Set for more details.
+func (svint SVarInt) Set(i int, bits Bits) error {
+	return VarInt(svint).Set(i, bits)
+}
+
+// GetSet swaps the provided bits with the integer inside SVarInt at the provided index.
+// See VarInt GetSet for more details.
+func (svint SVarInt) GetSet(i int, bits Bits) error {
+	return VarInt(svint).GetSet(i, bits)
+}
+
+// Add adds the provided bits to the integer inside SVarInt at the provided index.
+// In case the operation is used on invalid nil SVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of SVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the addition result overflows or underflows the bit len, the regular two's complement
+// semantic applies and extra ErrorAdditionOverflow warning is returned.
+func (svint SVarInt) Add(i int, bits Bits) error {
+	if err := svint.check(i, bits); err != nil {
+		return err
+	}
+	// The signed overflow happens only when both
+	// operands have the same sign, while the result
+	// has the opposite sign.
+	sa, sb := svint.sign(i), sign(bits)
+	_ = VarInt(svint).Add(i, bits)
+	if sa == sb && svint.sign(i) != sa {
+		return ErrorAdditionOverflow
+	}
+	return nil
+}
+
+// Sub subtracts the provided bits from the integer inside SVarInt at the provided index.
+// In case the operation is used on invalid nil SVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of SVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the subtraction result overflows or underflows the bit len, the regular two's complement
+// semantic applies and extra ErrorSubtractionUnderflow warning is returned.
+func (svint SVarInt) Sub(i int, bits Bits) error {
+	if err := svint.check(i, bits); err != nil {
+		return err
+	}
+	// The signed overflow happens only when operands
+	// have different signs, while the result has
+	// the opposite sign to the original integer.
+	sa, sb := svint.sign(i), sign(bits)
+	_ = VarInt(svint).Sub(i, bits)
+	if sa != sb && svint.sign(i) != sa {
+		return ErrorSubtractionUnderflow
+	}
+	return nil
+}
+
+// Mul multiplies the provided bits with the integer inside SVarInt at the provided index.
+// In case the operation is used on invalid nil SVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of SVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the multiplication result overflows or underflows the bit len, the integer is truncated and
+// extra ErrorMultiplicationOverflow warning is returned.
+func (svint SVarInt) Mul(i int, bits Bits) error {
+	if err := svint.check(i, bits); err != nil {
+		return err
+	}
+	// Multiply magnitudes of the operands
+	// and then restore the result sign.
+	sneg := svint.abs(i, bits)
+	err := VarInt(svint).Mul(i, svar(svint, false))
+	if svint.restore(i, sneg) {
+		err = ErrorMultiplicationOverflow
+	}
+	return err
+}
+
+// Div divides the provided bits with the integer inside SVarInt at the provided index.
+// The division result is truncated toward zero, akin to Go integer division.
+// In case the operation is used on invalid nil SVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of SVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+// In case the min integer value is divided by -1, the result overflows the bit len, the regular
+// two's complement semantic applies and extra ErrorDivisionOverflow warning is returned.
+func (svint SVarInt) Div(i int, bits Bits) error {
+	if err := svint.check(i, bits); err != nil {
+		return err
+	}
+	if bits.Empty() {
+		return ErrorDivisionByZero
+	}
+	// Divide magnitudes of the operands
+	// and then restore the result sign.
+	sneg := svint.abs(i, bits)
+	_ = VarInt(svint).Div(i, svar(svint, false))
+	if svint.restore(i, sneg) {
+		return ErrorDivisionOverflow
+	}
+	return nil
+}
+
+// Mod applies modulo operation to the provided bits and the integer inside SVarInt at the provided index.
+// The modulo result has the same sign as the original integer, akin to Go integer remainder.
+// In case the operation is used on invalid nil SVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of SVarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned.
+func (svint SVarInt) Mod(i int, bits Bits) error {
+	if err := svint.check(i, bits); err != nil {
+		return err
+	}
+	if bits.Empty() {
+		return ErrorDivisionByZero
+	}
+	// Take modulo of magnitudes of the operands and
+	// then restore the result sign from the integer,
+	// note that the remainder magnitude can't overflow.
+	sneg := svint.sign(i)
+	_ = svint.abs(i, bits)
+	_ = VarInt(svint).Mod(i, svar(svint, false))
+	_ = svint.restore(i, sneg)
+	return nil
+}
+
+// Not applies bitwise negation ^ operation to the integer inside SVarInt at the provided index.
+// See VarInt Not for more details.
+func (svint SVarInt) Not(i int) error {
+	return VarInt(svint).Not(i)
+}
+
+// And applies bitwise and & operation to the provided bits and the integer inside SVarInt at the provided index.
+// See VarInt And for more details.
+func (svint SVarInt) And(i int, bits Bits) error {
+	return VarInt(svint).And(i, bits)
+}
+
+// Or applies bitwise and | operation to the provided bits and the integer inside SVarInt at the provided index.
+// See VarInt Or for more details.
+func (svint SVarInt) Or(i int, bits Bits) error {
+	return VarInt(svint).Or(i, bits)
+}
+
+// Xor applies bitwise and ^ operation to the provided bits and the integer inside SVarInt at the provided index.
+// See VarInt Xor for more details.
+func (svint SVarInt) Xor(i int, bits Bits) error {
+	return VarInt(svint).Xor(i, bits)
+}
+
+// Rsh applies arithmetic right shift >> operation to the integer inside SVarInt at the provided index.
+// The vacated high bits are filled with the integer sign bit, akin to Go signed integer right shift.
+// In case the operation is used on invalid nil SVarInt, ErrorVarIntIsInvalid is returned.
+// In case negative shift is provided, ErrorShiftIsNegative is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of SVarInt, ErrorIndexIsOutOfRange is returned.
+func (svint SVarInt) Rsh(i, n int) error {
+	vint := VarInt(svint)
+	if err := vint.cshift(i, n); err != nil {
+		return err
+	}
+	// For non negative integers arithmetic shift is the same as logical shift.
+	// For negative integers apply logical shift to the inverted integer,
+	// so vacated high bits are filled with 0, then invert it back.
+	if !svint.sign(i) {
+		return vint.Rsh(i, n)
+	}
+	_ = vint.Not(i)
+	_ = vint.Rsh(i, n)
+	return vint.Not(i)
+}
+
+// Lsh applies left shift << operation to the integer inside SVarInt at the provided index.
+// See VarInt Lsh for more details.
+func (svint SVarInt) Lsh(i, n int) error {
+	return VarInt(svint).Lsh(i, n)
+}
+
+// check internal validator that verifies that the provided index
+// and bits are valid for SVarInt operations. It's needed as signed
+// operations inspect the integer sign before applying VarInt operations.
+func (svint SVarInt) check(i int, bits Bits) error {
+	return VarInt(svint).cbits(i, bits)
+}
+
+// sign returns true if the integer inside SVarInt at the provided index is negative.
+// It reads the sign bit which is the very first bit of the integer directly.
+func (svint SVarInt) sign(i int) bool {
+	bfrom := BitLen(VarInt(svint))*i + wsize*2
+	return svint[bfrom/wsize]<<(bfrom%wsize)>>(wsize-1) == 1
+}
+
+// neg applies two's complement negation to the integer inside SVarInt at the provided index.
+// It uses VarInt temp bits variable as 1 constant, so it doesn't allocate any new memory.
+func (svint SVarInt) neg(i int) {
+	vint := VarInt(svint)
+	one := bvar(vint, true)
+	one[1] = 1
+	_ = vint.Not(i)
+	_ = vint.Add(i, one)
+}
+
+// abs replaces the integer inside SVarInt at the provided index with its magnitude,
+// and copies magnitude of the provided bits into extra temp bits variable.
+// It returns true if the operation result is supposed to be negative.
+func (svint SVarInt) abs(i int, bits Bits) bool {
+	sa, sb := svint.sign(i), sign(bits)
+	if sa {
+		svint.neg(i)
+	}
+	sbits := svar(svint, false)
+	copy(sbits.Bytes(), bits.Bytes())
+	if sb {
+		neg(sbits)
+	}
+	return sa != sb
+}
+
+// restore applies the provided sign to the magnitude inside SVarInt at the provided index.
+// It returns true if the magnitude doesn't fit into the signed range of the bit len.
+func (svint SVarInt) restore(i int, sneg bool) bool {
+	if sneg {
+		svint.neg(i)
+	}
+	// The result sign could be different from the
+	// expected sign only on overflow or for 0 magnitude.
+	if svint.sign(i) == sneg {
+		return false
+	}
+	b := bvar(VarInt(svint), false)
+	_ = VarInt(svint).Get(i, b)
+	return !b.Empty()
+}
+
+// svar internal accessor that returns reserved extra Bits variable.
+// The extra Bits variable is collocated at the very end of SVarInt after
+// VarInt temp bits variable, so svar doesn't allocate any new memory.
+// svar is standalone function by choice to make it consistent with bvar.
+func svar(svint SVarInt, empty bool) Bits {
+	if svint == nil {
+		return nil
+	}
+	blen := BitLen(VarInt(svint))
+	// Calculate number of whole words plus
+	// one word if partial mod word is needed.
+	words := blen/wsize + (blen%wsize+wsize-1)/wsize
+	b := Bits(svint[len(svint)-words-1:])
+	if !empty {
+		return b
+	}
+	// Clear var bits state from prev manipulations.
+	for i := 1; i < len(b); i++ {
+		b[i] = 0
+	}
+	return b
+}
+
+// sign returns true if the provided bits are negative
+// two's complement integer of the bits bit len.
+func sign(bits Bits) bool {
+	blen := bits.BitLen()
+	if blen == 0 {
+		return false
+	}
+	return bits[(blen-1)/wsize+1]>>((blen-1)%wsize)&1 == 1
+}
+
+// neg applies two's complement negation to the provided bits in place.
+func neg(bits Bits) {
+	blen := bits.BitLen()
+	if blen == 0 {
+		return
+	}
+	carry := uint(1)
+	for i := 1; i < len(bits); i++ {
+		bits[i], carry = math_bits.Add(^bits[i], 0, carry)
+	}
+	// Truncate the high word back to the bit len.
+	if bdelta := wsize - blen%wsize; bdelta != wsize {
+		bits[len(bits)-1] = bits[len(bits)-1] << bdelta >> bdelta
+	}
+}
@@ -0,0 +1,272 @@
+package varint
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSVarIntNew(t *testing.T) {
+	table := map[string]struct {
+		blen  int
+		len   int
+		err   error
+		svint SVarInt
+	}{
+		"zero bits len should resolve in expected error": {
+			blen: 0,
+			len:  10,
+			err:  ErrorBitLengthIsNotPositive,
+		},
+		"negative len should resolve in expected error": {
+			blen: 10,
+			len:  -1,
+			err:  ErrorLengthIsNotPositive,
+		},
+		"positive bits len and len resolve in valid svint": {
+			blen:  120,
+			len:   5,
+			svint: SVarInt{5, 120, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 120, 0, 0, 120, 0, 0},
+		},
+		"small len should resolve in valid svint but warning": {
+			blen:  10,
+			len:   3,
+			err:   ErrorLengthIsNotEfficient,
+			svint: SVarInt{3, 10, 0, 10, 0, 10, 0},
+		},
+	}
+	for tname, tcase := range table {
+		test(tname, t, func(h h) {
+			svint, err := NewSVarInt(tcase.blen, tcase.len)
+			h.NoError(tcase.err, err)
+			h.Equal(tcase.svint, svint)
+		})
+	}
+}
+
+func TestSVarIntOperations(t *testing.T) {
+	const len = 10
+	test("Common", t, func(th h) {
+		table := map[string]struct {
+			svint SVarInt
+			i     int
+			bits  Bits
+			err   error
+		}{
+			"common operations should return invalid varint error": {
+				svint: nil,
+				i:     1,
+				bits:  NewBits(len, nil),
+				err:   ErrorVarIntIsInvalid,
+			},
+			"common operations should return negative index error": {
+				svint: th.NewSVarInt(len, len),
+				i:     -1,
+				bits:  NewBits(len, nil),
+				err:   ErrorIndexIsNegative,
+			},
+			"common operations should return index is out of range error": {
+				svint: th.NewSVarInt(len, len),
+				i:     2 * len,
+				bits:  NewBits(len, nil),
+				err:   ErrorIndexIsOutOfRange,
+			},
+			"common operations should return bit len cardinarity error": {
+				svint: th.NewSVarInt(len, len),
+				i:     1,
+				bits:  NewBits(2*len, nil),
+				err:   ErrorUnequalBitLengthCardinality,
+			},
+			"common operations should return a valid result for empty bits on valid index": {
+				svint: th.NewSVarInt(len, len),
+				i:     1,
+				bits:  NewBits(len, []uint{1}),
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				svint := tcase.svint
+				if svint != nil {
+					_ = svint.Set(1, NewBits(len, []uint{len}))
+				}
+				h.Equal(svint.Get(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Set(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.GetSet(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Add(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Sub(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Mul(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Div(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Mod(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.And(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Or(tcase.i, tcase.bits), tcase.err)
+				h.Equal(svint.Xor(tcase.i, tcase.bits), tcase.err)
+				if tcase.err != ErrorUnequalBitLengthCardinality {
+					h.Equal(svint.Not(tcase.i), tcase.err)
+					h.Equal(svint.Rsh(tcase.i, 1), tcase.err)
+					h.Equal(svint.Lsh(tcase.i, 1), tcase.err)
+				}
+			})
+		}
+	})
+	test("Arithmetic", t, func(th h) {
+		svint := th.NewSVarInt(len, len)
+		table := map[string]struct {
+			op   func(i int, bits Bits) error
+			n    int64
+			bits Bits
+			err  error
+		}{
+			"addition should return overflow error on bits overflow": {
+				op:   svint.Add,
+				n:    500,
+				bits: NewBitsBigIntSigned(len, big.NewInt(20)),
+				err:  ErrorAdditionOverflow,
+			},
+			"addition should return overflow error on bits underflow": {
+				op:   svint.Add,
+				n:    -500,
+				bits: NewBitsBigIntSigned(len, big.NewInt(-20)),
+				err:  ErrorAdditionOverflow,
+			},
+			"subtraction should return underflow error on bits underflow": {
+				op:   svint.Sub,
+				n:    -500,
+				bits: NewBitsBigIntSigned(len, big.NewInt(20)),
+				err:  ErrorSubtractionUnderflow,
+			},
+			"subtraction should return underflow error on bits overflow": {
+				op:   svint.Sub,
+				n:    500,
+				bits: NewBitsBigIntSigned(len, big.NewInt(-20)),
+				err:  ErrorSubtractionUnderflow,
+			},
+			"multiplication should return overflow error on bits overflow": {
+				op:   svint.Mul,
+				n:    -30,
+				bits: NewBitsBigIntSigned(len, big.NewInt(-30)),
+				err:  ErrorMultiplicationOverflow,
+			},
+			"multiplication should not return overflow error on min value": {
+				op:   svint.Mul,
+				n:    -256,
+				bits: NewBitsBigIntSigned(len, big.NewInt(2)),
+			},
+			"division should return zero division error on division by zero": {
+				op:   svint.Div,
+				n:    10,
+				bits: NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
+			"division should return overflow error on min value division by -1": {
+				op:   svint.Div,
+				n:    -512,
+				bits: NewBitsBigIntSigned(len, big.NewInt(-1)),
+				err:  ErrorDivisionOverflow,
+			},
+			"modulo should return zero division error on division by zero": {
+				op:   svint.Mod,
+				n:    10,
+				bits: NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				h.VarInt = VarInt(svint)
+				h.VarIntSet(1, NewBitsBigIntSigned(len, big.NewInt(tcase.n)))
+				h.Equal(tcase.op(1, tcase.bits), tcase.err)
+			})
+		}
+	})
+}
+
+// fuzzSVarInt runs the provided signed operation on fuzz bits pair
+// and compares the result with the provided big.Int operation result.
+// The result is expected to be truncated to the bits len and the warning
+// is expected to be returned only if the big.Int result doesn't fit the bits len.
+func fuzzSVarInt(f *testing.F, op func(svint SVarInt, i int, bits Bits) error, bop func(a, b *big.Int) *big.Int, warn error) {
+	const l = 3
+	fuzz(f, func(h h, b62 string) {
+		b1, b2 := h.NewBits2B62(b62)
+		blen := b1.BitLen()
+		// Make the second bits negative for every other input
+		// to cover all sign combinations of the operands.
+		if blen%2 == 0 {
+			neg(b2)
+		}
+		a, b := b1.BigIntSigned(), b2.BigIntSigned()
+		if b.Sign() == 0 {
+			h.Skip()
+		}
+		r := bop(a, b)
+		min := big.NewInt(0).Lsh(big.NewInt(-1), uint(blen-1))
+		max := big.NewInt(0).Not(min)
+		overflow := r.Cmp(min) < 0 || r.Cmp(max) > 0
+		svint := h.NewSVarInt(blen, l)
+		h.VarIntSet(0, b1)
+		h.VarIntSet(1, b1)
+		h.VarIntSet(2, b1)
+		err := op(svint, 1, b2)
+		if overflow {
+			h.Equal(err, warn)
+		} else {
+			h.NoError(err)
+		}
+		h.VarIntEqual(1, NewBitsBigIntSigned(blen, r))
+		// Check that others bits were not affected.
+		h.VarIntEqual(0, b1)
+		h.VarIntEqual(2, b1)
+	})
+}
+
+func FuzzSVarIntAdd(f *testing.F) {
+	fuzzSVarInt(f, SVarInt.Add, func(a, b *big.Int) *big.Int {
+		return big.NewInt(0).Add(a, b)
+	}, ErrorAdditionOverflow)
+}
+
+func FuzzSVarIntSub(f *testing.F) {
+	fuzzSVarInt(f, SVarInt.Sub, func(a, b *big.Int) *big.Int {
+		return big.NewInt(0).Sub(a, b)
+	}, ErrorSubtractionUnderflow)
+}
+
+func FuzzSVarIntMul(f *testing.F) {
+	fuzzSVarInt(f, SVarInt.Mul, func(a, b *big.Int) *big.Int {
+		return big.NewInt(0).Mul(a, b)
+	}, ErrorMultiplicationOverflow)
+}
+
+func FuzzSVarIntDiv(f *testing.F) {
+	fuzzSVarInt(f, SVarInt.Div, func(a, b *big.Int) *big.Int {
+		return big.NewInt(0).Quo(a, b)
+	}, ErrorDivisionOverflow)
+}
+
+func FuzzSVarIntMod(f *testing.F) {
+	fuzzSVarInt(f, SVarInt.Mod, func(a, b *big.Int) *big.Int {
+		return big.NewInt(0).Rem(a, b)
+	}, nil)
+}
+
+func FuzzSVarIntRsh(f *testing.F) {
+	const l = 3
+	fuzz(f, func(h h, b62 string) {
+		// Initialize fuzz bits and bootstrap signed big int,
+		// shift them both to the right in range [0, BitLen+1].
+		// Finally, compare calculated bit shifts with the bits.
+		bits := h.NewBitsB62(b62)
+		big, n := bits.BigIntSigned(), rnd.Int()%(bits.BitLen()+1)
+		big = big.Rsh(big, uint(n))
+		bsh := NewBitsBigIntSigned(bits.BitLen(), big)
+		svint := h.NewSVarInt(bits.BitLen(), l)
+		h.VarIntSet(1, bits)
+		h.VarIntSet(0, bits)
+		h.VarIntSet(2, bits)
+		// Shift bits to the right.
+		h.NoError(svint.Rsh(1, n))
+		h.VarIntEqual(1, bsh)
+		// Check that others bits were not affected.
+		h.VarIntEqual(0, bits)
+		h.VarIntEqual(2, bits)
+	})
+}
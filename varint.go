@@ -9,7 +9,8 @@ const wsize = math_bits.UintSize
 //
 // The purpose of VarInt to provide the maximum memory compact way to use and store unsigned custom bits integers.
 // It does so by storing all the integers adjacent to each other inside a continuous numeric byte slice.
-// It allocates the underlying numeric bytes slice only once on creation and doesn't expect to allocate any more memory afterwards.
+// It allocates the underlying numeric bytes slice only once on creation and doesn't expect to allocate any more memory afterwards,
+// unless it's explicitly grown with Append, Insert or Resize operations.
 // VarInt provides all the basic arithmetic and bitwise operations. To apply any of these operations, internal bits manipulations are required
 // which implies certain computational overhead. Thus providing a tradeoff between CPU time and memory.
 // Overhead grows lineraly, proportionally to bit len and is comparable with overhead from big.Int operations.
@@ -20,16 +21,22 @@ const wsize = math_bits.UintSize
 // However, note that VarInt is no way close to be optimized as well as big.Int, and provides diminishing returns as bit length grows above certain threshold.
 //
 // Currently, in a conscious decision multiple operations are implemented in favour of simplicity and not computational complexity,
-// this includes Mul that uses standard long multiplication instead of fast multiplication algorithms like Karatsuba multiplication,
-// and Div that uses standard slow division instead of fast division algorithms.
+// this includes Div that uses word level long division instead of subquadratic division algorithms.
 // The main rationale behind this choice is the fact that VarInt has the most efficiency when used for small and medium size integers
 // in the range of 1 to 5000 bit width, therefore asymptotic complexity should be less significant for this library.
+// Nevertheless, Mul uses standard long multiplication only for narrow integers and switches to Karatsuba multiplication for wide integers.
 // Note that VarInt carries a small fixed overhead internaly, it allocates 2 separate uint cells at the beginning of the numeric bytes slice
 // to store length and bit length. It also collocates extra Bits variable at the end of numeric bytes slice which is used internally
-// for many operations as a computation temporary buffer, including: Mul, Div, Mod, Sort.
+// for many operations as a computation temporary buffer, including: Mul, Div, Mod. For wide integers of 80 words and more,
+// it also collocates extra scratch words right after it, which are used exclusively by Karatsuba multiplication.
 // Currently, for simplicity and consistency most VarInt operations apply changes in place on the provided index and require
 // the provided Bits to have exactly the same bit len, otherwise ErrorUnequalBitLengthCardinality is returned.
-// Currently, VarInt provides only unsigned arithmetic.
+// Lenient counterparts, like AddAny, zero extend narrower Bits and accept wider Bits as long as their value fits.
+// Range counterparts, like AddRange, apply the operation to a continuous range of integers and aggregate the warnings into RangeError.
+// Element-wise counterparts, like AddVarInt, apply the operation to the integers of two VarInts at the same indexes.
+// Index-to-index counterparts, like AddAt, use another integer inside the same VarInt as the operand.
+// Saturating counterparts, like AddSat, clamp the integer to 0 or 2^blen-1 instead of wrapping, see also Overflow policy.
+// Currently, VarInt provides only unsigned arithmetic, for signed and modular arithmetic see SVarInt and MVarInt.
 type VarInt []uint
 
 // NewVarInt allocates and returns VarInt instance that is capable to
@@ -54,7 +61,7 @@ func NewVarInt(blen, len int) (VarInt, error) {
 	// Calculate number of whole words plus
 	// one word if partial mod word is needed.
 	words := blen/wsize + (blen%wsize+wsize-1)/wsize
-	vint := VarInt(make([]uint, cap+words+1))
+	vint := VarInt(make([]uint, cap+words+1+kwords(blen)))
 	vint[0] = uint(len)
 	vint[1] = uint(blen)
 	// Allocate protected space at the for
@@ -62,6 +69,8 @@ func NewVarInt(blen, len int) (VarInt, error) {
 	// This temp variable is useful for operations
 	// that require extra temp buffer like
 	// multiplication, division or sorting.
+	// For wide bit len, Karatsuba multiplication
+	// scratch words are also allocated after it.
 	vint[cap] = uint(blen)
 	// Lastly, check for len thresholds, in case the
 	// thresholds are violated still return a valid
@@ -84,22 +93,16 @@ func NewVarInt(blen, len int) (VarInt, error) {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 func (vint VarInt) Get(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.get(i, bits)
+}
+
+// get internal core of Get operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) get(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
 	bfrom, bto := blen*i+wsize*2, blen*(i+1)-1+wsize*2
@@ -141,22 +144,16 @@ func (vint VarInt) Get(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 func (vint VarInt) Set(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.set(i, bits)
+}
+
+// set internal core of Set operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) set(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -205,22 +202,16 @@ func (vint VarInt) Set(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 func (vint VarInt) GetSet(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.getset(i, bits)
+}
+
+// getset internal core of GetSet operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) getset(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -275,24 +266,29 @@ func (vint VarInt) GetSet(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 // In case the addition result overflows the bit len, the regular unsigned semantic applies and
-// extra ErrorAdditionOverflow warning is returned.
+// extra ErrorAdditionOverflow warning is returned, unless other VarInt Overflow policy is used.
 func (vint VarInt) Add(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.add(i, bits)
+}
+
+// add internal core of Add operation,
+// the provided arguments are expected to be validated by the caller.
+// It applies the wrapping core directly only for the default overflow policy,
+// otherwise it falls back to the element-wise core that honors the policy.
+func (vint VarInt) add(i int, bits Bits) error {
+	if OverflowPolicy(vint) != OverflowWrap {
+		return vint.addv(i, operand{bits: bits, blen: BitLen(vint)}, vint)
+	}
+	return vint.addw(i, bits)
+}
+
+// addw internal wrapping core of Add operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) addw(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -371,24 +367,29 @@ func (vint VarInt) Add(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 // In case the subtraction result underflows the integer, the regular unsigned semantic applies and
-// extra ErrorSubtractionUnderflow warning is returned.
+// extra ErrorSubtractionUnderflow warning is returned, unless other VarInt Overflow policy is used.
 func (vint VarInt) Sub(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.sub(i, bits)
+}
+
+// sub internal core of Sub operation,
+// the provided arguments are expected to be validated by the caller.
+// It applies the wrapping core directly only for the default overflow policy,
+// otherwise it falls back to the element-wise core that honors the policy.
+func (vint VarInt) sub(i int, bits Bits) error {
+	if OverflowPolicy(vint) != OverflowWrap {
+		return vint.subv(i, operand{bits: bits, blen: BitLen(vint)}, vint)
+	}
+	return vint.subw(i, bits)
+}
+
+// subw internal wrapping core of Sub operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) subw(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -458,24 +459,29 @@ func (vint VarInt) Sub(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 // In case the multiplication result overflows the bit len, the integer is trucated and
-// extra ErrorMultiplicationOverflow warning is returned.
+// extra ErrorMultiplicationOverflow warning is returned, unless other VarInt Overflow policy is used.
 func (vint VarInt) Mul(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.mul(i, bits)
+}
+
+// mul internal core of Mul operation,
+// the provided arguments are expected to be validated by the caller.
+// It applies the wrapping core directly only for the default overflow policy and narrow bit len,
+// otherwise it falls back to the element-wise core that honors the policy and uses Karatsuba multiplication.
+func (vint VarInt) mul(i int, bits Bits) error {
+	if OverflowPolicy(vint) != OverflowWrap || kvar(vint) != nil {
+		return vint.mulv(i, operand{bits: bits, blen: BitLen(vint)}, vint)
+	}
+	return vint.mulw(i, bits)
+}
+
+// mulw internal wrapping core of Mul operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) mulw(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bvar := bvar(vint, true)
 	bitsb, bvarb := bits.Bytes(), bvar.Bytes()
 	// Calculate starting and ending bit with
@@ -495,13 +501,6 @@ func (vint VarInt) Mul(i int, bits Bits) error {
 		// Iterate from high to low word and
 		// accumulate the combined words.
 		for j, k := 0, hiw; k >= low; k, j = k-1, j+1 {
-			// If out of temp bits buffer is riched,
-			// set carry flag and jump to next iteration.
-			w := i + j
-			if w >= maxl {
-				overflow = b != 0
-				break
-			}
 			var bk uint
 			switch {
 			// Special case, the point where low == high word is reached
@@ -524,26 +523,36 @@ func (vint VarInt) Mul(i int, bits Bits) error {
 			default:
 				bk = vint[k-1]<<adjrbshift | vint[k]>>rbshift
 			}
+			// If out of temp bits buffer is reached,
+			// any non zero product overflows the result,
+			// set overflow flag and jump to next iteration.
+			w := i + j
+			if w >= maxl {
+				overflow = overflow || b != 0 && bk != 0
+				continue
+			}
 			var c1, c2 uint
 			hi, lo := math_bits.Mul(bk, b)
 			lo, c1 = math_bits.Add(lo, carry, 0)
 			lo, c2 = math_bits.Add(lo, bvarb[w], 0)
 			bvarb[w] = lo
 			carry = hi + c1 + c2
-			// For the very last word check if final
-			// lower word result doesn't fit into bit len.
-			if k == low && carry == 0 && math_bits.Len(lo) > blen%wsize {
-				carry = 1
-			}
 		}
 		if carry > 0 {
 			overflow = true
 			carry = 0
 		}
 	}
-	// After multiplication is done set bits var
-	// back to i-th integer and check for any error.
-	_ = vint.Set(i, bvar)
+	// After multiplication is done truncate bits var
+	// high word excess bits, so they don't leak into
+	// adjacent integer, then set bits var back to
+	// i-th integer and check for any error.
+	if bdelta := wsize - blen%wsize; bdelta != wsize {
+		hi := len(bvarb) - 1
+		overflow = overflow || bvarb[hi]>>(wsize-bdelta) != 0
+		bvarb[hi] = bvarb[hi] << bdelta >> bdelta
+	}
+	_ = vint.set(i, bvar)
 	if overflow {
 		return ErrorMultiplicationOverflow
 	}
@@ -557,80 +566,99 @@ func (vint VarInt) Mul(i int, bits Bits) error {
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 // In case the division by zero is attempted, ErrorDivisionByZero is returned
 func (vint VarInt) Div(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
+	if bits.Empty() {
+		return ErrorDivisionByZero
 	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	return vint.div(i, bits)
+}
+
+// div internal core of Div operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) div(i int, bits Bits) error {
+	return vint.divv(i, operand{bits: bits, blen: BitLen(vint)}, vint)
+}
+
+// Mod applies modulo operation to the provided bits and the integer inside VarInt at the provided index.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned
+func (vint VarInt) Mod(i int, bits Bits) error {
+	if err := vint.cbits(i, bits); err != nil {
+		return err
+	}
+	if bits.Empty() {
+		return ErrorDivisionByZero
+	}
+	return vint.mod(i, bits)
+}
+
+// mod internal core of Mod operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) mod(i int, bits Bits) error {
+	return vint.modv(i, operand{bits: bits, blen: BitLen(vint)}, vint)
+}
+
+// DivMod divides the integer inside VarInt at the provided index by the provided divisor bits
+// and sets the remainder into the provided rem bits, both are calculated in a single division.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case any provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
+// In case the division by zero is attempted, ErrorDivisionByZero is returned
+func (vint VarInt) DivMod(i int, divisor, rem Bits) error {
+	if err := vint.cany(i); err != nil {
+		return err
 	}
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
+	if divisor.BitLen() != blen || rem.BitLen() != blen {
 		return ErrorUnequalBitLengthCardinality
 	}
-	if bits.Empty() {
+	if divisor.Empty() {
 		return ErrorDivisionByZero
 	}
-	bvar := bvar(vint, true)
-	// Calculate starting and ending bit with
-	// starting and ending index inside vint respectively.
-	bfrom, bto := blen*i+wsize*2, blen*(i+1)-1+wsize*2
-	low, hiw := bfrom/wsize, bto/wsize
-	// Calculate left and right shifts to fix the uint result.
-	lbshift, rbshift := bfrom-low*wsize, (hiw+1)*wsize-1-bto
-	// Run bit len iterations to perform slow restoring division method here.
-	// Extra swaps with tmp bits variable is needed to perform all
-	// necessary left shifts and subtracts.
-	for j := 0; j < blen; j++ {
-		// Start with quotient Q in vint number and
-		// partial reminder R in tmp bits variable.
-		//
-		// Do a left shift across both RQ, by
-		// picking last bit from quotient Q in vint number
-		// and applying left shift. Then swap partial reminder R
-		// with vint number, apply left shift and set last memorized bit.
-		lb := vint[low] << lbshift >> (wsize - 1)
-		_ = vint.Lsh(i, 1)
-		_ = vint.GetSet(i, bvar)
-		_ = vint.Lsh(i, 1)
-		vint[hiw] |= lb << rbshift
-		// Subtract partial reminder R in in vint number with divisor
-		// if it's greater or equal to R then set last bit of quotient
-		// Q in tmp bits variable, otherwise cancel Subtraction by addition.
-		switch vint.Sub(i, bits) {
-		case ErrorSubtractionUnderflow:
-			_ = vint.Add(i, bits)
-		case nil:
-			bvar[1] |= 1
-		}
-		// Finally swap R and Q back to restore the iteration state.
-		_ = vint.GetSet(i, bvar)
-	}
+	return vint.divmod(i, divisor, rem)
+}
+
+// divmod internal core of DivMod operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) divmod(i int, divisor, rem Bits) error {
+	_ = vint.divremv(i, operand{bits: divisor, blen: BitLen(vint)}, vint, true)
+	// Copy the remainder left inside tmp bits variable,
+	// only after the divisor bits are no longer used.
+	copy(rem.Bytes(), bvar(vint, false).Bytes())
 	return nil
 }
 
-// Mod applies modulo operation to the provided bits and the integer inside VarInt at the provided index.
+// DivModTo divides the integer inside VarInt at the provided index i by the provided divisor bits
+// and sets the remainder into the integer at the provided index j, both are calculated in a single division.
+// It writes the remainder directly, so it doesn't require any Bits variable and doesn't allocate any memory.
+// In case both provided indexes are the same, the integer at the index is set to the remainder.
 // In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
-// In case negative index is provided, ErrorIndexIsNegative is returned.
-// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case any negative index is provided, ErrorIndexIsNegative is returned.
+// In case any provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 // In case the division by zero is attempted, ErrorDivisionByZero is returned
-func (vint VarInt) Mod(i int, bits Bits) error {
-	// For modulo we can just use the fact that
-	// in division operation the reminder is left
-	// inside tmp bits variable. Reuse all the
-	// logic validation from div here.
-	if err := vint.Div(i, bits); err != nil {
+func (vint VarInt) DivModTo(i int, divisor Bits, j int) error {
+	if err := vint.cat(i, j); err != nil {
 		return err
 	}
-	// Get tmp bits variable with reminder inise,
-	// don't clear the previous and swap it with vint.
-	_ = vint.GetSet(i, bvar(vint, false))
+	blen := BitLen(vint)
+	if divisor.BitLen() != blen {
+		return ErrorUnequalBitLengthCardinality
+	}
+	if divisor.Empty() {
+		return ErrorDivisionByZero
+	}
+	_ = vint.divremv(i, operand{bits: divisor, blen: blen}, vint, true)
+	bvarb := bvar(vint, false).Bytes()
+	for k := 0; k < (blen+wsize-1)/wsize; k++ {
+		wset(vint, j, k, bvarb[k])
+	}
 	return nil
 }
 
@@ -639,18 +667,15 @@ func (vint VarInt) Mod(i int, bits Bits) error {
 // In case negative index is provided, ErrorIndexIsNegative is returned.
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 func (vint VarInt) Not(i int) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cany(i); err != nil {
+		return err
 	}
+	return vint.not(i)
+}
+
+// not internal core of Not operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) not(i int) error {
 	blen := BitLen(vint)
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -698,22 +723,16 @@ func (vint VarInt) Not(i int) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 func (vint VarInt) And(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.and(i, bits)
+}
+
+// and internal core of And operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) and(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -762,22 +781,16 @@ func (vint VarInt) And(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 func (vint VarInt) Or(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.or(i, bits)
+}
+
+// or internal core of Or operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) or(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -826,22 +839,16 @@ func (vint VarInt) Or(i int, bits Bits) error {
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 // In case the provided bits has different bit len, ErrorUnequalBitLengthCardinality is returned.
 func (vint VarInt) Xor(i int, bits Bits) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cbits(i, bits); err != nil {
+		return err
 	}
+	return vint.xor(i, bits)
+}
+
+// xor internal core of Xor operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) xor(i int, bits Bits) error {
 	blen := BitLen(vint)
-	if blenx := bits.BitLen(); blenx != blen {
-		return ErrorUnequalBitLengthCardinality
-	}
 	bitsb := bits.Bytes()
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -890,22 +897,15 @@ func (vint VarInt) Xor(i int, bits Bits) error {
 // In case negative index is provided, ErrorIndexIsNegative is returned.
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 func (vint VarInt) Rsh(i, n int) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that valid shift is provided.
-	if n < 0 {
-		return ErrorShiftIsNegative
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cshift(i, n); err != nil {
+		return err
 	}
+	return vint.rsh(i, n)
+}
+
+// rsh internal core of Rsh operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) rsh(i, n int) error {
 	blen := BitLen(vint)
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -974,22 +974,15 @@ loop:
 // In case negative index is provided, ErrorIndexIsNegative is returned.
 // In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
 func (vint VarInt) Lsh(i, n int) error {
-	// Check explicitly for invalid number.
-	if vint == nil {
-		return ErrorVarIntIsInvalid
-	}
-	// Check that valid shift is provided.
-	if n < 0 {
-		return ErrorShiftIsNegative
-	}
-	// Check that non negative index was provided.
-	if i < 0 {
-		return ErrorIndexIsNegative
-	}
-	// Check that requested index is inside varint range.
-	if length := Len(vint); i >= length {
-		return ErrorIndexIsOutOfRange
+	if err := vint.cshift(i, n); err != nil {
+		return err
 	}
+	return vint.lsh(i, n)
+}
+
+// lsh internal core of Lsh operation,
+// the provided arguments are expected to be validated by the caller.
+func (vint VarInt) lsh(i, n int) error {
 	blen := BitLen(vint)
 	// Calculate starting and ending bit with
 	// starting and ending index inside vint respectively.
@@ -1051,3 +1044,98 @@ loop:
 	}
 	return nil
 }
+
+// Rol applies left bit rotation operation to the integer inside VarInt at the provided index.
+// The rotation is applied within the exact bit len of VarInt, so the provided shift is taken modulo bit len.
+// It uses the tmp bits variable collocated on VarInt, so Rol doesn't allocate any new memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative shift is provided, ErrorShiftIsNegative is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) Rol(i, n int) error {
+	if err := vint.cshift(i, n); err != nil {
+		return err
+	}
+	return vint.rol(i, n%BitLen(vint))
+}
+
+// Ror applies right bit rotation operation to the integer inside VarInt at the provided index.
+// The rotation is applied within the exact bit len of VarInt, so the provided shift is taken modulo bit len.
+// It uses the tmp bits variable collocated on VarInt, so Ror doesn't allocate any new memory.
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative shift is provided, ErrorShiftIsNegative is returned.
+// In case negative index is provided, ErrorIndexIsNegative is returned.
+// In case the provided index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+func (vint VarInt) Ror(i, n int) error {
+	if err := vint.cshift(i, n); err != nil {
+		return err
+	}
+	// Right rotation is the left rotation
+	// by the complementary shift.
+	blen := BitLen(vint)
+	return vint.rol(i, (blen-n%blen)%blen)
+}
+
+// rol internal core of Rol and Ror operations, the provided
+// shift is expected to be already taken modulo bit len.
+func (vint VarInt) rol(i, n int) error {
+	if n == 0 {
+		return nil
+	}
+	blen := BitLen(vint)
+	// Copy the integer words into tmp bits variable and
+	// combine both left shifted and right shifted words,
+	// the excess bits are truncated by the word setter.
+	bvarb := bvar(vint, false).Bytes()
+	for k := range bvarb {
+		bvarb[k] = wget(vint, i, k)
+	}
+	for k := range bvarb {
+		wset(vint, i, k, wshift(bvarb, -n, k)|wshift(bvarb, blen-n, k))
+	}
+	return nil
+}
+
+// cany internal helper that validates
+// the provided index against VarInt.
+func (vint VarInt) cany(i int) error {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return ErrorVarIntIsInvalid
+	}
+	// Check that non negative index was provided.
+	if i < 0 {
+		return ErrorIndexIsNegative
+	}
+	// Check that requested index is inside varint range.
+	if length := Len(vint); i >= length {
+		return ErrorIndexIsOutOfRange
+	}
+	return nil
+}
+
+// cbits internal helper that validates
+// the provided index and bits against VarInt.
+func (vint VarInt) cbits(i int, bits Bits) error {
+	if err := vint.cany(i); err != nil {
+		return err
+	}
+	if blenx := bits.BitLen(); blenx != BitLen(vint) {
+		return ErrorUnequalBitLengthCardinality
+	}
+	return nil
+}
+
+// cshift internal helper that validates
+// the provided index and shift against VarInt.
+func (vint VarInt) cshift(i, n int) error {
+	// Check explicitly for invalid number.
+	if vint == nil {
+		return ErrorVarIntIsInvalid
+	}
+	// Check that valid shift is provided.
+	if n < 0 {
+		return ErrorShiftIsNegative
+	}
+	return vint.cany(i)
+}
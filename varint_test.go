@@ -105,6 +105,10 @@ func TestVarIntOperations(t *testing.T) {
 				h.Equal(h.VarInt.And(tcase.i, tcase.bits), tcase.err)
 				h.Equal(h.VarInt.Or(tcase.i, tcase.bits), tcase.err)
 				h.Equal(h.VarInt.Xor(tcase.i, tcase.bits), tcase.err)
+				h.Equal(h.VarInt.DivModTo(tcase.i, tcase.bits, 0), tcase.err)
+				h.Equal(h.VarInt.DivMod(tcase.i, tcase.bits, tcase.bits), tcase.err)
+				_, err := h.VarInt.CmpBits(tcase.i, tcase.bits)
+				h.Equal(err, tcase.err)
 				if tcase.err != ErrorUnequalBitLengthCardinality {
 					h.Equal(h.VarInt.Not(tcase.i), tcase.err)
 				}
@@ -151,8 +155,16 @@ func TestVarIntOperations(t *testing.T) {
 				}
 				h.Equal(h.VarInt.Rsh(tcase.i, tcase.n), tcase.err)
 				h.Equal(h.VarInt.Lsh(tcase.i, tcase.n), tcase.err)
+				h.Equal(h.VarInt.Rol(tcase.i, tcase.n), tcase.err)
+				h.Equal(h.VarInt.Ror(tcase.i, tcase.n), tcase.err)
 			})
 		}
+		// Unlike VarInt rotations, Bits rotations don't return any error,
+		// so negative shift rotates the bits in the opposite direction.
+		bits := NewBits(len, []uint{1})
+		th.Equal(bits.Rol(-1), NewBits(len, []uint{1 << (len - 1)}))
+		th.Equal(bits.Ror(-1), NewBits(len, []uint{2}))
+		th.Equal(bits.Rol(-len-2), bits.Ror(2))
 	})
 	test("Arithmetic", t, func(th h) {
 		vint := th.NewVarInt(len, len)
@@ -186,6 +198,20 @@ func TestVarIntOperations(t *testing.T) {
 				bits: NewBits(len, nil),
 				err:  ErrorDivisionByZero,
 			},
+			"combined division should return zero division error on division by zero": {
+				op: func(i int, bits Bits) error {
+					return vint.DivMod(i, bits, NewBits(len, nil))
+				},
+				bits: NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
+			"combined division with remainder index should return zero division error on division by zero": {
+				op: func(i int, bits Bits) error {
+					return vint.DivModTo(i, bits, 0)
+				},
+				bits: NewBits(len, nil),
+				err:  ErrorDivisionByZero,
+			},
 		}
 		for tname, tcase := range table {
 			test(tname, th.T, func(h h) {
@@ -195,6 +221,115 @@ func TestVarIntOperations(t *testing.T) {
 			})
 		}
 	})
+	test("Division", t, func(h h) {
+		// Divide random integers built from the words that are
+		// likely to trigger quotient estimation corrections
+		// by both single word and multi word divisors and
+		// compare the results with the same big.Int operations.
+		patterns := []uint{0, 1, ^uint(0), ^uint(0) >> 1, 1 << (wsize - 1)}
+		word := func() uint {
+			if rnd.Int()%2 == 0 {
+				return patterns[rnd.Int()%5]
+			}
+			return uint(rnd.Uint64())
+		}
+		for k := 0; k < 2000; k++ {
+			blen := []int{1, 7, 63, 64, 65, 128, 129, 200, 1000, 5000}[rnd.Int()%10]
+			words := (blen + wsize - 1) / wsize
+			a, b := make([]uint, words), make([]uint, rnd.Int()%words+1)
+			for x := range a {
+				a[x] = word()
+			}
+			for x := range b {
+				b[x] = word()
+			}
+			ab, bb := NewBits(blen, a), NewBits(blen, b)
+			if bb.Empty() {
+				bb = NewBits(blen, []uint{1})
+			}
+			vint := h.NewVarInt(blen, 3)
+			h.VarIntSet(0, ab)
+			h.VarIntSet(1, ab)
+			h.VarIntSet(2, ab)
+			q, r := new(big.Int).QuoRem(ab.BigInt(), bb.BigInt(), new(big.Int))
+			h.NoError(vint.Div(0, bb))
+			h.VarIntEqual(0, NewBitsBits(blen, NewBitsBigInt(q)))
+			h.NoError(vint.Mod(2, bb))
+			h.VarIntEqual(2, NewBitsBits(blen, NewBitsBigInt(r)))
+			// Combined division has to yield exactly
+			// the same quotient and remainder.
+			rem := NewBits(blen, nil)
+			h.VarIntSet(0, ab)
+			h.NoError(vint.DivMod(0, bb, rem))
+			h.VarIntEqual(0, NewBitsBits(blen, NewBitsBigInt(q)))
+			h.Equal(rem, NewBitsBits(blen, NewBitsBigInt(r)))
+			h.VarIntSet(2, ab)
+			h.NoError(vint.DivModTo(2, bb, 0))
+			h.VarIntEqual(2, NewBitsBits(blen, NewBitsBigInt(q)))
+			h.VarIntEqual(0, NewBitsBits(blen, NewBitsBigInt(r)))
+			// Check that others bits were not affected.
+			h.VarIntEqual(1, ab)
+			// Base conversions are built on top of division.
+			base := rnd.Int()%61 + 2
+			h.Equal(string(ab.To(base)), ab.BigInt().Text(base))
+		}
+	})
+	test("Multiplication", t, func(h h) {
+		// Multiply the integers which products overflow only inside the high word
+		// excess bits or only outside of the temp bits buffer, the product has
+		// to be truncated and the overflow has to be detected in both cases,
+		// while the product that fits exactly has to yield no warning.
+		table := []struct {
+			blen int
+			a, b []uint
+			err  error
+		}{
+			{blen: 65, a: []uint{0, 1}, b: []uint{2}, err: ErrorMultiplicationOverflow},
+			{blen: 65, a: []uint{1 << (wsize - 1)}, b: []uint{2}, err: nil},
+			{blen: 65, a: []uint{1 << (wsize - 1)}, b: []uint{4}, err: ErrorMultiplicationOverflow},
+			{blen: 128, a: []uint{0, 1}, b: []uint{0, 1}, err: ErrorMultiplicationOverflow},
+			{blen: 128, a: []uint{0, 1}, b: []uint{1}, err: nil},
+			{blen: 129, a: []uint{0, 0, 1}, b: []uint{0, 1}, err: ErrorMultiplicationOverflow},
+			{blen: 129, a: []uint{0, 0, 1}, b: []uint{1}, err: nil},
+		}
+		for _, tcase := range table {
+			ab, bb := NewBits(tcase.blen, tcase.a), NewBits(tcase.blen, tcase.b)
+			vint := h.NewVarInt(tcase.blen, 3)
+			h.VarIntSet(0, ab)
+			h.VarIntSet(1, ab)
+			h.VarIntSet(2, ab)
+			h.Equal(vint.Mul(1, bb), tcase.err)
+			p := new(big.Int).Mul(ab.BigInt(), bb.BigInt())
+			h.VarIntEqual(1, NewBitsBits(tcase.blen, NewBitsBigInt(p)))
+			// Check that the truncated excess bits
+			// don't leak into the adjacent integers.
+			h.VarIntEqual(0, ab)
+			h.VarIntEqual(2, ab)
+		}
+		// Multiply random integers with random bit len and
+		// compare the results and the overflow detection
+		// with the same big.Int operations.
+		for k := 0; k < 1000; k++ {
+			blen := []int{1, 7, 63, 64, 65, 128, 129, 200, 1000, 6000}[rnd.Int()%10]
+			ab, bb := NewBitsRand(blen, rnd), NewBitsRand(blen, rnd)
+			// Shrink the multiplier randomly,
+			// so not every product overflows.
+			bb = NewBitsBits(blen, NewBitsRand(rnd.Int()%blen+1, rnd))
+			vint := h.NewVarInt(blen, 3)
+			h.VarIntSet(0, ab)
+			h.VarIntSet(1, ab)
+			h.VarIntSet(2, ab)
+			p := new(big.Int).Mul(ab.BigInt(), bb.BigInt())
+			var expected error
+			if p.BitLen() > blen {
+				expected = ErrorMultiplicationOverflow
+			}
+			h.Equal(vint.Mul(1, bb), expected)
+			h.VarIntEqual(1, NewBitsBits(blen, NewBitsBigInt(p)))
+			h.VarIntEqual(0, ab)
+			h.VarIntEqual(2, ab)
+		}
+	})
 }
 
 func FuzzVarIntSetAndGet(f *testing.F) {
@@ -313,6 +448,12 @@ func FuzzVarIntMul(f *testing.F) {
 		if !h.NoError(vint.Mul(1, b2), ErrorMultiplicationOverflow) {
 			h.VarIntEqual(1, bmul)
 		}
+		// Multiply vint the bits again to check overflow
+		// against the product with exact bits len.
+		bmul = NewBitsBigInt(big.NewInt(1).Mul(bmul.BigInt(), b2.BigInt()))
+		overflow := bmul.BitLen() > mblen
+		h.Equal(vint.Mul(1, b2) == ErrorMultiplicationOverflow, overflow)
+		h.VarIntEqual(1, NewBitsBits(mblen, bmul))
 		// Check that others bits were not affected.
 		h.VarIntEqual(0, b1)
 		h.VarIntEqual(2, b1)
@@ -509,6 +650,60 @@ func FuzzVarIntLsh(f *testing.F) {
 	})
 }
 
+func FuzzVarIntRol(f *testing.F) {
+	const l = 3
+	fuzz(f, func(h h, b62 string) {
+		// Initialize fuzz bits and bootstrap big int,
+		// rotate them both to the left in range [0, 2*BitLen+1].
+		// Finally, compare calculated bit rotations with the bits.
+		bits := h.NewBitsB62(b62)
+		blen := bits.BitLen()
+		b, n := bits.BigInt(), rnd.Int()%(2*blen+1)
+		lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+		b = new(big.Int).Or(new(big.Int).Lsh(b, uint(n%blen)), new(big.Int).Rsh(b, uint(blen-n%blen)))
+		bsh := NewBitsBits(blen, NewBitsBigInt(b.Mod(b, lim)))
+		vint := h.NewVarInt(blen, l)
+		h.VarIntSet(1, bits)
+		h.VarIntSet(0, bits)
+		h.VarIntSet(2, bits)
+		// Rotate bits to the left.
+		h.NoError(vint.Rol(1, n))
+		h.VarIntEqual(1, bsh)
+		h.Equal(bits.Rol(n), bsh)
+		h.Equal(bits.Ror(-n), bsh)
+		// Check that others bits were not affected.
+		h.VarIntEqual(0, bits)
+		h.VarIntEqual(2, bits)
+	})
+}
+
+func FuzzVarIntRor(f *testing.F) {
+	const l = 3
+	fuzz(f, func(h h, b62 string) {
+		// Initialize fuzz bits and bootstrap big int,
+		// rotate them both to the right in range [0, 2*BitLen+1].
+		// Finally, compare calculated bit rotations with the bits.
+		bits := h.NewBitsB62(b62)
+		blen := bits.BitLen()
+		b, n := bits.BigInt(), rnd.Int()%(2*blen+1)
+		lim := new(big.Int).Lsh(big.NewInt(1), uint(blen))
+		b = new(big.Int).Or(new(big.Int).Rsh(b, uint(n%blen)), new(big.Int).Lsh(b, uint(blen-n%blen)))
+		bsh := NewBitsBits(blen, NewBitsBigInt(b.Mod(b, lim)))
+		vint := h.NewVarInt(blen, l)
+		h.VarIntSet(1, bits)
+		h.VarIntSet(0, bits)
+		h.VarIntSet(2, bits)
+		// Rotate bits to the right.
+		h.NoError(vint.Ror(1, n))
+		h.VarIntEqual(1, bsh)
+		h.Equal(bits.Ror(n), bsh)
+		h.Equal(bits.Rol(-n), bsh)
+		// Check that others bits were not affected.
+		h.VarIntEqual(0, bits)
+		h.VarIntEqual(2, bits)
+	})
+}
+
 func BenchmarkVarIntOperations(b *testing.B) {
 	bench("Benchmark Arithmetic Operations", b, func(b *testing.B) {
 		bench("100000000 integers, 4 bits width", b, func(b *testing.B) {
@@ -0,0 +1,221 @@
+package varint
+
+// View provides zero-copy window over the continuous range of integers inside VarInt.
+//
+// View shares the numeric bytes slice with the parent VarInt, so it never allocates or copies any integers,
+// and all the changes applied through View are immediately visible in the parent VarInt and vice versa.
+// View supports only the basic subset of VarInt operations: Get, Set, GetSet, Add, Sub, Mul, Div, Mod,
+// Not, And, Or, Xor, Rsh and Lsh, with indexes relative to the window start, along with Len, BitLen and Slice,
+// so a big VarInt could be partitioned between multiple consumers without any copying.
+// Any other VarInt operations, like Any, range, index-to-index or saturating counterparts, are not supported on View.
+// Note that View is bound to the numeric bytes slice of VarInt at the moment of slicing,
+// so in case the parent VarInt is reallocated afterwards with Append, Insert or Resize operations,
+// the View keeps operating on the stale numeric bytes slice and has to be sliced again.
+// Zero value View is not valid and any operation on it returns ErrorVarIntIsInvalid.
+type View struct {
+	vint     VarInt
+	from, to int
+}
+
+// Slice returns View over the integers inside VarInt in range [from, to).
+// In case the operation is used on invalid nil VarInt, ErrorVarIntIsInvalid is returned.
+// In case negative from index is provided, ErrorIndexIsNegative is returned.
+// In case the provided to index is greater than len of VarInt, ErrorIndexIsOutOfRange is returned.
+// In case the provided range is empty, ErrorLengthIsNotPositive is returned.
+// In case of any error zero value View is returned.
+func (vint VarInt) Slice(from, to int) (View, error) {
+	if err := vint.crange(from, to); err != nil {
+		return View{}, err
+	}
+	return View{vint: vint, from: from, to: to}, nil
+}
+
+// Slice returns View over the integers inside View in range [from, to),
+// the resulting View is still bound directly to the parent VarInt.
+// See VarInt Slice for more details.
+func (view View) Slice(from, to int) (View, error) {
+	// Check explicitly for invalid view.
+	if view.vint == nil {
+		return View{}, ErrorVarIntIsInvalid
+	}
+	// Check that non negative index was provided.
+	if from < 0 {
+		return View{}, ErrorIndexIsNegative
+	}
+	// Check that requested range is inside view range.
+	if to > view.Len() {
+		return View{}, ErrorIndexIsOutOfRange
+	}
+	// Check that requested range is not empty.
+	if to <= from {
+		return View{}, ErrorLengthIsNotPositive
+	}
+	return View{vint: view.vint, from: view.from + from, to: view.from + to}, nil
+}
+
+// Len returns length of the View instance.
+// It's safe to use on zero value View, 0 is returned.
+func (view View) Len() int {
+	return view.to - view.from
+}
+
+// BitLen returns bit length of the View instance.
+// It's safe to use on zero value View, 0 is returned.
+func (view View) BitLen() int {
+	return BitLen(view.vint)
+}
+
+// Get sets the provided bits to the integer inside View at the provided index.
+// See VarInt Get for more details.
+func (view View) Get(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Get(view.from+i, bits)
+}
+
+// Set sets the provided bits into the integer inside View at the provided index.
+// See VarInt Set for more details.
+func (view View) Set(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Set(view.from+i, bits)
+}
+
+// GetSet swaps the provided bits with the integer inside View at the provided index.
+// See VarInt GetSet for more details.
+func (view View) GetSet(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.GetSet(view.from+i, bits)
+}
+
+// Add adds the provided bits to the integer inside View at the provided index.
+// See VarInt Add for more details.
+func (view View) Add(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Add(view.from+i, bits)
+}
+
+// Sub subtracts the provided bits from the integer inside View at the provided index.
+// See VarInt Sub for more details.
+func (view View) Sub(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Sub(view.from+i, bits)
+}
+
+// Mul multiplies the provided bits with the integer inside View at the provided index.
+// See VarInt Mul for more details.
+func (view View) Mul(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Mul(view.from+i, bits)
+}
+
+// Div divides the integer inside View at the provided index by the provided bits.
+// See VarInt Div for more details.
+func (view View) Div(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Div(view.from+i, bits)
+}
+
+// Mod applies modulo operation to the integer inside View at the provided index and the provided bits.
+// See VarInt Mod for more details.
+func (view View) Mod(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Mod(view.from+i, bits)
+}
+
+// Not applies bitwise negation ^ operation to the integer inside View at the provided index.
+// See VarInt Not for more details.
+func (view View) Not(i int) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Not(view.from + i)
+}
+
+// And applies bitwise and & operation to the integer inside View at the provided index and the provided bits.
+// See VarInt And for more details.
+func (view View) And(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.And(view.from+i, bits)
+}
+
+// Or applies bitwise or | operation to the integer inside View at the provided index and the provided bits.
+// See VarInt Or for more details.
+func (view View) Or(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Or(view.from+i, bits)
+}
+
+// Xor applies bitwise xor ^ operation to the integer inside View at the provided index and the provided bits.
+// See VarInt Xor for more details.
+func (view View) Xor(i int, bits Bits) error {
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Xor(view.from+i, bits)
+}
+
+// Rsh applies right shift >> operation to the integer inside View at the provided index.
+// See VarInt Rsh for more details.
+func (view View) Rsh(i, n int) error {
+	// Check that valid shift is provided,
+	// before the index to keep VarInt errors order.
+	if view.vint != nil && n < 0 {
+		return ErrorShiftIsNegative
+	}
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Rsh(view.from+i, n)
+}
+
+// Lsh applies left shift << operation to the integer inside View at the provided index.
+// See VarInt Lsh for more details.
+func (view View) Lsh(i, n int) error {
+	// Check that valid shift is provided,
+	// before the index to keep VarInt errors order.
+	if view.vint != nil && n < 0 {
+		return ErrorShiftIsNegative
+	}
+	if err := view.check(i); err != nil {
+		return err
+	}
+	return view.vint.Lsh(view.from+i, n)
+}
+
+// check internal helper that validates the provided
+// index relatively to the view window, the rest of
+// checks is delegated to the parent VarInt operations.
+func (view View) check(i int) error {
+	// Check explicitly for invalid view.
+	if view.vint == nil {
+		return ErrorVarIntIsInvalid
+	}
+	// Check that non negative index was provided.
+	if i < 0 {
+		return ErrorIndexIsNegative
+	}
+	// Check that requested index is inside view range.
+	if i >= view.Len() {
+		return ErrorIndexIsOutOfRange
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package varint
+
+import (
+	"testing"
+)
+
+func TestViewSlice(t *testing.T) {
+	const len = 10
+	test("Slice", t, func(th h) {
+		table := map[string]struct {
+			vint     VarInt
+			from, to int
+			err      error
+			vlen     int
+		}{
+			"slice should return invalid varint error": {
+				vint: nil,
+				from: 1,
+				to:   2,
+				err:  ErrorVarIntIsInvalid,
+			},
+			"slice should return negative index error": {
+				vint: th.NewVarInt(len, len),
+				from: -1,
+				to:   2,
+				err:  ErrorIndexIsNegative,
+			},
+			"slice should return index is out of range error": {
+				vint: th.NewVarInt(len, len),
+				from: 1,
+				to:   len + 1,
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"slice should return not positive length error": {
+				vint: th.NewVarInt(len, len),
+				from: 2,
+				to:   2,
+				err:  ErrorLengthIsNotPositive,
+			},
+			"slice should return valid view on valid range": {
+				vint: th.NewVarInt(len, len),
+				from: 2,
+				to:   len,
+				vlen: len - 2,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				view, err := tcase.vint.Slice(tcase.from, tcase.to)
+				h.Equal(err, tcase.err)
+				h.Equal(view.Len(), tcase.vlen)
+				if err == nil {
+					h.Equal(view.BitLen(), len)
+					// Nested view is still bound directly to the parent varint.
+					nview, err := view.Slice(1, view.Len())
+					h.NoError(err)
+					h.Equal(nview, View{vint: tcase.vint, from: tcase.from + 1, to: tcase.to})
+					_, err = view.Slice(0, view.Len()+1)
+					h.Equal(err, ErrorIndexIsOutOfRange)
+				}
+			})
+		}
+	})
+}
+
+func TestViewOperations(t *testing.T) {
+	const len = 10
+	test("Errors", t, func(th h) {
+		table := map[string]struct {
+			view View
+			i    int
+			n    int
+			bits Bits
+			err  error
+		}{
+			"view operations should return invalid varint error": {
+				view: View{},
+				i:    1,
+				bits: NewBits(len, nil),
+				err:  ErrorVarIntIsInvalid,
+			},
+			"view operations should return negative index error": {
+				view: View{vint: th.NewVarInt(len, len), from: 2, to: 5},
+				i:    -1,
+				bits: NewBits(len, nil),
+				err:  ErrorIndexIsNegative,
+			},
+			"view operations should return index is out of range error": {
+				view: View{vint: th.NewVarInt(len, len), from: 2, to: 5},
+				i:    3,
+				bits: NewBits(len, nil),
+				err:  ErrorIndexIsOutOfRange,
+			},
+			"view operations should return bit len cardinarity error": {
+				view: View{vint: th.NewVarInt(len, len), from: 2, to: 5},
+				i:    1,
+				bits: NewBits(2*len, nil),
+				err:  ErrorUnequalBitLengthCardinality,
+			},
+			"view shift operations should return negative shift error": {
+				view: View{vint: th.NewVarInt(len, len), from: 2, to: 5},
+				i:    3,
+				n:    -1,
+				err:  ErrorShiftIsNegative,
+			},
+		}
+		for tname, tcase := range table {
+			test(tname, th.T, func(h h) {
+				if tcase.n < 0 {
+					h.Equal(tcase.view.Rsh(tcase.i, tcase.n), tcase.err)
+					h.Equal(tcase.view.Lsh(tcase.i, tcase.n), tcase.err)
+					return
+				}
+				h.Equal(tcase.view.Get(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Set(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.GetSet(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Add(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Sub(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Mul(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Div(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Mod(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.And(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Or(tcase.i, tcase.bits), tcase.err)
+				h.Equal(tcase.view.Xor(tcase.i, tcase.bits), tcase.err)
+				if tcase.err != ErrorUnequalBitLengthCardinality {
+					h.Equal(tcase.view.Not(tcase.i), tcase.err)
+					h.Equal(tcase.view.Rsh(tcase.i, 1), tcase.err)
+					h.Equal(tcase.view.Lsh(tcase.i, 1), tcase.err)
+				}
+			})
+		}
+	})
+	test("Shared", t, func(h h) {
+		// Apply the same random operations to the view and
+		// directly to the copy of the parent varint on shifted
+		// indexes, then check that both parents are equal.
+		blen := rnd.Int()%150 + 1
+		l := rnd.Int()%100 + 10
+		from := rnd.Int() % (l / 2)
+		to := from + rnd.Int()%(l-from) + 1
+		vint, _ := NewVarInt(blen, l)
+		for i := 0; i < l; i++ {
+			_ = vint.Set(i, NewBitsRand(blen, rnd))
+		}
+		vintc := append(VarInt(nil), vint...)
+		view, err := vint.Slice(from, to)
+		h.NoError(err)
+		for k := 0; k < 100; k++ {
+			i, n, b := rnd.Int()%(to-from), rnd.Int()%blen, NewBitsRand(blen, rnd)
+			switch op := rnd.Int() % 10; op {
+			case 0:
+				h.NoError(view.Set(i, b))
+				h.NoError(vintc.Set(from+i, b))
+			case 1:
+				h.NoError(view.Add(i, b), ErrorAdditionOverflow)
+				h.NoError(vintc.Add(from+i, b), ErrorAdditionOverflow)
+			case 2:
+				h.NoError(view.Sub(i, b), ErrorSubtractionUnderflow)
+				h.NoError(vintc.Sub(from+i, b), ErrorSubtractionUnderflow)
+			case 3:
+				h.NoError(view.Mul(i, b), ErrorMultiplicationOverflow)
+				h.NoError(vintc.Mul(from+i, b), ErrorMultiplicationOverflow)
+			case 4:
+				h.NoError(view.Div(i, b), ErrorDivisionByZero)
+				h.NoError(vintc.Div(from+i, b), ErrorDivisionByZero)
+			case 5:
+				h.NoError(view.Xor(i, b))
+				h.NoError(vintc.Xor(from+i, b))
+			case 6:
+				h.NoError(view.Not(i))
+				h.NoError(vintc.Not(from + i))
+			case 7:
+				h.NoError(view.Rsh(i, n))
+				h.NoError(vintc.Rsh(from+i, n))
+			case 8:
+				h.NoError(view.Lsh(i, n))
+				h.NoError(vintc.Lsh(from+i, n))
+			default:
+				// GetSet swaps the provided bits, so use a copy.
+				bc := NewBitsBits(blen, b)
+				h.NoError(view.GetSet(i, b))
+				h.NoError(vintc.GetSet(from+i, bc))
+				h.Equal(b, bc)
+			}
+		}
+		// Temp bits variable might hold different leftovers,
+		// so clear them before comparing the parents.
+		_, _ = bvar(vint, true), bvar(vintc, true)
+		h.Equal(vint, vintc)
+	})
+}